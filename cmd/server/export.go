@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"go-blog/internal/model"
+	"go-blog/internal/repository"
+	"go-blog/internal/routes"
+	"go-blog/internal/service"
+	"go-blog/internal/util"
+)
+
+// runExportStatic 将已发布的公开文章渲染为静态站点（首页、文章、分类、标签、归档与 RSS），并复制上传文件。
+//
+//	server export-static [-out public] [-base-url https://blog.example.com] [-title 标题] [-page-size 10]
+func runExportStatic(args []string) error {
+	fset := flag.NewFlagSet("export-static", flag.ContinueOnError)
+	out := fset.String("out", "public", "output directory (replaced as a whole)")
+	baseURL := fset.String("base-url", util.EnvString("SITE_BASE_URL", ""), "site URL used for feed links and sub-path deployments")
+	title := fset.String("title", util.EnvString("SITE_TITLE", "go-blog"), "site title")
+	pageSize := fset.Int("page-size", 10, "posts per index page")
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+	if fset.NArg() != 0 || *out == "" {
+		fset.Usage()
+		return fmt.Errorf("usage: export-static [-out <dir>] [-base-url <url>] [-title <title>] [-page-size <n>]")
+	}
+
+	model.InitDB()
+	svc := service.NewStaticExportService(
+		repository.NewPostRepository(model.DB),
+		repository.NewCategoryRepository(model.DB),
+		repository.NewTagRepository(model.DB),
+		repository.NewCommentRepository(model.DB),
+	)
+	stats, err := svc.Export(context.Background(), service.StaticExportOptions{
+		OutDir:    *out,
+		AssetsDir: routes.UploadRoot,
+		BaseURL:   *baseURL,
+		Title:     *title,
+		PageSize:  *pageSize,
+	})
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(os.Stdout).Encode(stats)
+}
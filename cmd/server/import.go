@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"go-blog/internal/dto"
+	"go-blog/internal/importer"
+	"go-blog/internal/model"
+	"go-blog/internal/repository"
+	"go-blog/internal/routes"
+	"go-blog/internal/service"
+)
+
+// importFlags 各导入子命令共用的参数。
+type importFlags struct {
+	fset     *flag.FlagSet
+	userID   *uint
+	dryRun   *bool
+	category *string
+}
+
+func newImportFlags(name string) *importFlags {
+	fset := flag.NewFlagSet(name, flag.ContinueOnError)
+	return &importFlags{
+		fset:     fset,
+		userID:   fset.Uint("user", 0, "owner user id of the imported posts"),
+		dryRun:   fset.Bool("dry-run", false, "report what would be imported without writing anything"),
+		category: fset.String("category", "", "category for posts without one"),
+	}
+}
+
+// parse 解析参数，要求恰好一个输入路径；needUser 为 true 时 -user 必填。
+func (f *importFlags) parse(args []string, needUser bool) (string, error) {
+	if err := f.fset.Parse(args); err != nil {
+		return "", err
+	}
+	if (needUser && *f.userID == 0) || f.fset.NArg() != 1 {
+		f.fset.Usage()
+		return "", fmt.Errorf("usage: %s -user <id> [-dry-run] [-category <name>] <path>", f.fset.Name())
+	}
+	return f.fset.Arg(0), nil
+}
+
+func (f *importFlags) options() service.ImportOptions {
+	return service.ImportOptions{
+		UserID:          *f.userID,
+		DryRun:          *f.dryRun,
+		DefaultCategory: *f.category,
+	}
+}
+
+// runImportMarkdown 从目录或 ZIP 导入 Hugo 风格的 Markdown 文章。
+//
+//	server import-markdown -user 1 [-dry-run] [-category 名称] <目录或.zip>
+func runImportMarkdown(args []string) error {
+	f := newImportFlags("import-markdown")
+	src, err := f.parse(args, true)
+	if err != nil {
+		return err
+	}
+	fsys, closeFn, err := importer.OpenSource(src)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	report, err := newImportService().ImportMarkdown(context.Background(), fsys, f.options())
+	return printReport(report, err)
+}
+
+// runImportWordPress 导入 WordPress 导出文件（WXR）。
+//
+//	server import-wordpress -user 1 [-dry-run] [-category 名称] <export.xml>
+func runImportWordPress(args []string) error {
+	f := newImportFlags("import-wordpress")
+	src, err := f.parse(args, true)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	report, err := newImportService().ImportWordPress(context.Background(), file, f.options())
+	return printReport(report, err)
+}
+
+// runImportDisqus 导入 Disqus 评论导出文件。
+//
+//	server import-disqus [-dry-run] <disqus.xml>
+func runImportDisqus(args []string) error {
+	f := newImportFlags("import-disqus")
+	src, err := f.parse(args, false)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	report, err := newImportService().ImportDisqus(context.Background(), file, f.options())
+	return printReport(report, err)
+}
+
+// newImportService 连接数据库并组装导入服务。
+func newImportService() *service.ImportService {
+	model.InitDB()
+	return service.NewImportService(
+		model.DB,
+		repository.NewUserRepository(model.DB),
+		repository.NewPostRepository(model.DB),
+		repository.NewCategoryRepository(model.DB),
+		repository.NewTagRepository(model.DB),
+		repository.NewCommentRepository(model.DB),
+		repository.NewImportRepository(model.DB),
+		service.NewUploadService(repository.NewUploadRepository(routes.UploadRoot)),
+		nil, // 相关推荐缓存在服务进程内，按 TTL 自然过期
+	)
+}
+
+// printReport 以 JSON 输出导入报告。
+func printReport(report *dto.ImportReport, err error) error {
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
@@ -1,17 +1,65 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"go-blog/internal/routes"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
+// shutdownTimeout 优雅退出时等待进行中请求（含实时推送连接）结束的最长时间。
+const shutdownTimeout = 10 * time.Second
+
+// commands 命令行子命令表：名称 -> 入口（参数不含子命令名）。
+var commands = map[string]func(args []string) error{
+	"import-markdown":  runImportMarkdown,
+	"import-wordpress": runImportWordPress,
+	"import-disqus":    runImportDisqus,
+	"export-static":    runExportStatic,
+}
+
 func main() {
+	// 子命令：导入、静态导出等离线任务
+	if len(os.Args) > 1 {
+		if cmd, ok := commands[os.Args[1]]; ok {
+			if err := cmd(os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+	}
+
+	// 后台任务的生命周期：服务停止后再取消，确保请求期间记录的浏览量能落库
+	tasks, cancelTasks := context.WithCancel(context.Background())
+	router, wait := routes.SetupRouter(tasks)
 
-	router := routes.SetupRouter()
+	// 启动服务，收到 SIGINT/SIGTERM 后优雅退出
+	srv := &http.Server{Addr: ":8080", Handler: router}
+	errc := make(chan error, 1)
+	go func() { errc <- srv.ListenAndServe() }()
 
-	// 启动服务
-	err := router.Run(":8080")
-	if err != nil {
-		log.Fatal(err)
+	sig, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	select {
+	case err := <-errc:
+		if !errors.Is(err, http.ErrServerClosed) {
+			cancelTasks()
+			wait()
+			log.Fatal(err)
+		}
+	case <-sig.Done():
+		log.Println("shutting down")
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		if err := srv.Shutdown(ctx); err != nil {
+			log.Printf("server shutdown error: %v", err)
+		}
+		cancel()
 	}
+	cancelTasks()
+	wait()
 }
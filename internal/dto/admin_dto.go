@@ -5,6 +5,7 @@ type AdminDashboardResp struct {
 	Metrics  AdminDashboardMetrics `json:"metrics"`
 	Recent   AdminRecentStats      `json:"recent"`
 	TopPosts []AdminTopPost        `json:"top_posts"`
+	TopViews []AdminTopViewedPost  `json:"top_viewed_posts"`
 }
 
 // AdminDashboardMetrics 汇总总量指标。
@@ -12,6 +13,7 @@ type AdminDashboardMetrics struct {
 	Users    int64 `json:"users"`
 	Posts    int64 `json:"posts"`
 	Comments int64 `json:"comments"`
+	Views    int64 `json:"views"`
 }
 
 // AdminRecentStats 近7天新增指标。
@@ -28,6 +30,13 @@ type AdminTopPost struct {
 	CommentCount int64  `json:"comment_count"`
 }
 
+// AdminTopViewedPost 浏览量最高的文章。
+type AdminTopViewedPost struct {
+	PostID    uint   `json:"post_id"`
+	Title     string `json:"title"`
+	ViewCount int64  `json:"view_count"`
+}
+
 // AdminUserQuery 用户列表查询条件。
 type AdminUserQuery struct {
 	Page     int
@@ -51,4 +60,5 @@ type AdminCommentQuery struct {
 	Keyword  string
 	UserID   *uint
 	PostID   *uint
+	Status   string
 }
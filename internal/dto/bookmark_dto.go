@@ -0,0 +1,50 @@
+package dto
+
+import "time"
+
+// AddBookmarkReq 收藏文章请求体，可指定归入的阅读清单。
+type AddBookmarkReq struct {
+	ReadingListId *uint `json:"reading_list_id"`
+}
+
+// CreateReadingListReq 创建阅读清单请求体。
+type CreateReadingListReq struct {
+	Name        string `json:"name" binding:"required,min=1,max=100"`
+	Description string `json:"description" binding:"omitempty,max=500"`
+	Visibility  string `json:"visibility" binding:"omitempty,oneof=private public"`
+}
+
+// UpdateReadingListReq 更新阅读清单请求体，空字段不覆盖。
+type UpdateReadingListReq struct {
+	Name        *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Description *string `json:"description" binding:"omitempty,max=500"`
+	Visibility  *string `json:"visibility" binding:"omitempty,oneof=private public"`
+}
+
+// PostBrief 文章简要信息
+type PostBrief struct {
+	Id     uint      `json:"id"`
+	Title  string    `json:"title"`
+	Status string    `json:"status"`
+	User   UserBrief `json:"user"`
+}
+
+// BookmarkResp 收藏响应
+type BookmarkResp struct {
+	Id            uint      `json:"id"`
+	PostId        uint      `json:"post_id"`
+	ReadingListId *uint     `json:"reading_list_id,omitempty"`
+	Post          PostBrief `json:"post"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ReadingListResp 阅读清单响应，公开清单附带分享地址。
+type ReadingListResp struct {
+	Id          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Visibility  string    `json:"visibility"`
+	ShareURL    string    `json:"share_url,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
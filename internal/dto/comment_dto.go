@@ -1,15 +1,37 @@
 package dto
 
+import (
+	"time"
+
+	"go-blog/internal/model"
+)
+
 // CreateCommentReq 创建评论请求（支持 parent_id 用于回复）
 type CreateCommentReq struct {
 	Content  string `json:"content" binding:"required,min=1,max=1000"`
 	PostId   uint   `json:"post_id" binding:"required"`
 	ParentId *uint  `json:"parent_id,omitempty"`
+
+	IP        string `json:"-"` // 由 handler 填充，供垃圾检测使用
+	UserAgent string `json:"-"`
+	Password  string `json:"-"` // 密码保护文章的访问密码，由 handler 从 X-Post-Password 填充
 }
 
 // ReplyCommentReq 回复评论请求
 type ReplyCommentReq struct {
 	Content string `json:"content" binding:"required,min=1,max=1000"`
+
+	IP        string `json:"-"`
+	UserAgent string `json:"-"`
+	Password  string `json:"-"`
+}
+
+// UpdateCommentReq 编辑评论请求
+type UpdateCommentReq struct {
+	Content string `json:"content" binding:"required,min=1,max=1000"`
+
+	IP        string `json:"-"`
+	UserAgent string `json:"-"`
 }
 
 // UserBrief 用户简要信息
@@ -20,10 +42,85 @@ type UserBrief struct {
 
 // CommentResp 评论响应
 type CommentResp struct {
-	Id       uint         `json:"id"`
-	Content  string       `json:"content"`
-	User     UserBrief    `json:"user"`
-	ParentId *uint        `json:"parent_id,omitempty"`
-	PostId   uint         `json:"post_id"`
+	Id       uint          `json:"id"`
+	Content  string        `json:"content"`
+	User     UserBrief     `json:"user"`
+	ParentId *uint         `json:"parent_id,omitempty"`
+	PostId   uint          `json:"post_id"`
 	Replies  []CommentResp `json:"replies,omitempty"`
+
+	Status     string     `json:"status"`                // 审核状态；非 approved 的评论只有作者本人能看到
+	Deleted    bool       `json:"deleted,omitempty"`     // 已删除的占位评论：内容为 "[deleted]"，不返回作者
+	Edited     bool       `json:"edited"`                // 是否编辑过
+	EditedAt   *time.Time `json:"edited_at,omitempty"`   // 最后一次编辑时间
+	Depth      int        `json:"depth"`                 // 层级，顶层评论为 0
+	ReplyCount int64      `json:"reply_count"`           // 直接回复总数
+	NextCursor string     `json:"next_cursor,omitempty"` // 预加载回复之后的游标，为空表示已全部返回
+
+	Reactions   map[string]int64   `json:"reactions,omitempty"`
+	MyReactions []string           `json:"my_reactions,omitempty"`
+	Mentions    []model.MentionRef `json:"mentions,omitempty"` // 正文中的 @提及，可渲染为用户链接
+}
+
+// CommentDeletedEvent 评论流中的删除事件：评论被删除、变为占位或不再公开
+type CommentDeletedEvent struct {
+	Id        uint `json:"id"`
+	PostId    uint `json:"post_id"`
+	Tombstone bool `json:"tombstone"`
+}
+
+// CommentListQuery 文章评论列表查询参数
+type CommentListQuery struct {
+	Page     int
+	PageSize int
+	Replies  int // 每条顶层评论预加载的回复数
+}
+
+// CommentPageResp 文章评论分页结果：分页针对顶层评论，comment_count 为含回复的评论总数
+type CommentPageResp struct {
+	Page         int           `json:"page"`
+	PageSize     int           `json:"page_size"`
+	Total        int64         `json:"total"`
+	CommentCount int64         `json:"comment_count"`
+	List         []CommentResp `json:"list"`
+}
+
+// CommentRepliesResp 评论回复的游标分页结果
+type CommentRepliesResp struct {
+	List       []CommentResp `json:"list"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+	HasMore    bool          `json:"has_more"`
+}
+
+// ModerateCommentsReq 批量审核评论请求
+type ModerateCommentsReq struct {
+	Ids []uint `json:"ids" binding:"required,min=1,max=200"`
+}
+
+// ModerationCommentResp 审核队列中的评论
+type ModerationCommentResp struct {
+	Id        uint      `json:"id"`
+	Content   string    `json:"content"`
+	Status    string    `json:"status"`
+	User      UserBrief `json:"user"`
+	PostId    uint      `json:"post_id"`
+	PostTitle string    `json:"post_title"`
+	ParentId  *uint     `json:"parent_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CommentRevisionResp 评论的一个历史版本
+type CommentRevisionResp struct {
+	Id        uint      `json:"id"`
+	Content   string    `json:"content"`    // 被替换前的内容
+	Editor    UserBrief `json:"editor"`     // 进行该次编辑的用户
+	CreatedAt time.Time `json:"created_at"` // 编辑时间
+}
+
+// CommentRevisionsResp 评论当前内容及历史版本（最新的在前）
+type CommentRevisionsResp struct {
+	Id        uint                  `json:"id"`
+	Content   string                `json:"content"`
+	EditedAt  *time.Time            `json:"edited_at,omitempty"`
+	Revisions []CommentRevisionResp `json:"revisions"`
 }
@@ -0,0 +1,54 @@
+package dto
+
+// 导入结果中单篇文章的处理动作
+const (
+	ImportActionCreate = "create"
+	ImportActionUpdate = "update"
+	ImportActionError  = "error"
+)
+
+// ImportReport 批量导入报告（dry-run 时为预演结果，不写库）
+type ImportReport struct {
+	DryRun        bool               `json:"dry_run"`
+	Total         int                `json:"total"`
+	Created       int                `json:"created"`
+	Updated       int                `json:"updated"`
+	Failed        int                `json:"failed"`
+	NewCategories []string           `json:"new_categories"`
+	NewTags       []string           `json:"new_tags"`
+	Images        int                `json:"images"`
+	Posts         []ImportPostResult `json:"posts"`
+
+	// 以下仅 WordPress / Disqus 导入使用
+	NewUsers []string            `json:"new_users,omitempty"`
+	Comments *ImportCommentStats `json:"comments,omitempty"`
+	Warnings []string            `json:"warnings,omitempty"`
+}
+
+// ImportCommentStats 评论导入统计
+type ImportCommentStats struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"` // 未通过审核、垃圾、已删除或 pingback
+	Failed  int `json:"failed"`
+}
+
+// ImportPostResult 单篇文章的导入结果
+type ImportPostResult struct {
+	Path     string              `json:"path"`
+	Slug     string              `json:"slug,omitempty"`
+	Title    string              `json:"title,omitempty"`
+	Action   string              `json:"action"`
+	PostId   uint                `json:"post_id,omitempty"`
+	Status   string              `json:"status,omitempty"`
+	Images   []ImportImageResult `json:"images,omitempty"`
+	Warnings []string            `json:"warnings,omitempty"`
+	Error    string              `json:"error,omitempty"`
+}
+
+// ImportImageResult 文章中图片的重新上传结果
+type ImportImageResult struct {
+	Ref   string `json:"ref"`
+	URL   string `json:"url,omitempty"`
+	Error string `json:"error,omitempty"`
+}
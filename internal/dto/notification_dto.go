@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+// NotificationResp 通知响应：相同事件未读时合并，message 为合并后的描述（如 "bob 等 5 人对你的文章表态"）
+type NotificationResp struct {
+	Id         uint       `json:"id"`
+	Type       string     `json:"type"`
+	Message    string     `json:"message"`
+	TargetType string     `json:"target_type"` // post / comment，合并通知指向最近一次事件的目标
+	TargetId   uint       `json:"target_id"`
+	PostId     uint       `json:"post_id"`
+	Actor      *UserBrief `json:"actor,omitempty"` // 最近一次的触发人，系统通知为空
+	ActorCount int64      `json:"actor_count"`     // 去重后的触发人数
+	Count      int64      `json:"count"`           // 合并的事件数
+	Detail     string     `json:"detail,omitempty"`
+	Read       bool       `json:"read"`
+	ReadAt     *time.Time `json:"read_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// NotificationPageResp 通知分页结果，附带未读总数与各类型未读数
+type NotificationPageResp struct {
+	Page         int                `json:"page"`
+	PageSize     int                `json:"page_size"`
+	Total        int64              `json:"total"`
+	Unread       int64              `json:"unread"`
+	UnreadByType map[string]int64   `json:"unread_by_type"`
+	List         []NotificationResp `json:"list"`
+}
+
+// UnreadCountResp 未读通知数
+type UnreadCountResp struct {
+	Unread       int64            `json:"unread"`
+	UnreadByType map[string]int64 `json:"unread_by_type"`
+}
+
+// UpdateNotificationPreferencesReq 更新通知偏好：类型 -> 是否接收，未列出的类型保持不变
+type UpdateNotificationPreferencesReq struct {
+	Preferences map[string]bool `json:"preferences" binding:"required"`
+}
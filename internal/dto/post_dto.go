@@ -1,19 +1,64 @@
 package dto
 
+import "time"
+
 // CreatePostReq 用于创建文章请求体
 type CreatePostReq struct {
-	Title      string `json:"title"   binding:"required,min=1,max=200"`
-	Content    string `json:"content" binding:"required"`
-	CategoryId uint   `json:"category_id" binding:"required"`
-	TagIds     []uint `json:"tag_ids"`
-	Status     string `json:"status" binding:"omitempty,oneof=draft published"`
+	Title             string `json:"title"   binding:"required,min=1,max=200"`
+	Content           string `json:"content" binding:"required"`
+	CategoryId        uint   `json:"category_id" binding:"required"`
+	TagIds            []uint `json:"tag_ids"`
+	Status            string `json:"status"` // 初始状态，默认 draft；取值与流转规则由 PostService 校验
+	Visibility        string `json:"visibility" binding:"omitempty,oneof=public private unlisted password"`
+	Password          string `json:"password" binding:"omitempty,min=4,max=72"`                        // visibility=password 时必填
+	ViewerIds         []uint `json:"viewer_ids"`                                                       // 私密文章的指定读者
+	CommentModeration string `json:"comment_moderation" binding:"omitempty,oneof=all first_time none"` // 评论审核策略，空表示沿用站点设置
 }
 
 // UpdatePostReq 用于更新文章请求体
 type UpdatePostReq struct {
-	Title      *string `json:"title"   binding:"omitempty,min=1,max=200"`
-	Content    *string `json:"content" binding:"omitempty"`
-	CategoryID *uint   `json:"category_id" binding:"omitempty,gt=0"`                   // 分类可选更新
-	Status     *string `json:"status"      binding:"omitempty,oneof=draft published"` // 状态：draft / published
-	TagIDs     []uint  `json:"tag_ids"`
+	Title             *string `json:"title"   binding:"omitempty,min=1,max=200"`
+	Content           *string `json:"content" binding:"omitempty"`
+	CategoryID        *uint   `json:"category_id" binding:"omitempty,gt=0"` // 分类可选更新
+	Status            *string `json:"status"`                               // 状态：draft / in_review / approved / published，流转由 PostService 校验
+	TagIDs            []uint  `json:"tag_ids"`
+	Visibility        *string `json:"visibility"  binding:"omitempty,oneof=public private unlisted password"`
+	Password          *string `json:"password"    binding:"omitempty,min=4,max=72"`                             // 设置/修改访问密码
+	ViewerIDs         []uint  `json:"viewer_ids"`                                                               // 不为 null 时整体替换指定读者
+	CommentModeration *string `json:"comment_moderation" binding:"omitempty,oneof=all first_time none inherit"` // 评论审核策略，inherit 表示恢复为站点设置
+	Version           *uint64 `json:"version"`                                                                  // 读取时的版本号，与 If-Match 二选一；不一致时拒绝更新
+}
+
+// AddCoAuthorReq 邀请合著者请求体
+type AddCoAuthorReq struct {
+	UserId uint `json:"user_id" binding:"required"`
+}
+
+// TransitionPostReq 文章状态流转请求体（提交审核、批准、退回修改、发布等）
+type TransitionPostReq struct {
+	Status  string `json:"status" binding:"required"`
+	Comment string `json:"comment" binding:"omitempty,max=1000"` // 审核意见；退回修改时必填
+}
+
+// RelatedPostResp 相关文章推荐项
+type RelatedPostResp struct {
+	Id         uint      `json:"id"`
+	Title      string    `json:"title"`
+	User       UserBrief `json:"user"`
+	CategoryId uint      `json:"category_id"`
+	Score      float64   `json:"score"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ArchiveMonthResp 归档：某月文章数
+type ArchiveMonthResp struct {
+	Month int   `json:"month"`
+	Count int64 `json:"count"`
+}
+
+// ArchiveYearResp 归档：某年文章数及各月明细（倒序）
+type ArchiveYearResp struct {
+	Year   int                `json:"year"`
+	Count  int64              `json:"count"`
+	Months []ArchiveMonthResp `json:"months"`
 }
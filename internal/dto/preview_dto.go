@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+// CreatePreviewReq 创建草稿预览链接请求体，有效期默认 72 小时。
+type CreatePreviewReq struct {
+	ExpiresInHours int `json:"expires_in_hours" binding:"omitempty,min=1,max=720"`
+}
+
+// PreviewLinkResp 预览链接响应
+type PreviewLinkResp struct {
+	Id        uint      `json:"id"`
+	PostId    uint      `json:"post_id"`
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PreviewPostResp 凭预览链接只读查看的草稿内容，不含作者账号的其它信息。
+type PreviewPostResp struct {
+	Id        uint          `json:"id"`
+	Title     string        `json:"title"`
+	Content   string        `json:"content"`
+	Category  *CategoryResp `json:"category,omitempty"`
+	Tags      []TagResp     `json:"tags"`
+	Authors   []UserBrief   `json:"authors"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
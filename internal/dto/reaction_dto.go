@@ -0,0 +1,14 @@
+package dto
+
+// ToggleReactionReq 切换表态请求体。
+type ToggleReactionReq struct {
+	Emoji string `json:"emoji" binding:"required,max=32"`
+}
+
+// ReactionResp 切换表态后的结果与最新计数。
+type ReactionResp struct {
+	Emoji       string           `json:"emoji"`
+	Active      bool             `json:"active"`
+	Reactions   map[string]int64 `json:"reactions"`
+	MyReactions []string         `json:"my_reactions"`
+}
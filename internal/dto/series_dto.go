@@ -0,0 +1,41 @@
+package dto
+
+import "time"
+
+// CreateSeriesReq 创建系列请求体，post_ids 按顺序作为系列各篇。
+type CreateSeriesReq struct {
+	Title       string `json:"title" binding:"required,min=1,max=200"`
+	Description string `json:"description" binding:"omitempty,max=1000"`
+	PostIds     []uint `json:"post_ids"`
+}
+
+// UpdateSeriesReq 更新系列请求体，空字段不覆盖。
+type UpdateSeriesReq struct {
+	Title       *string `json:"title" binding:"omitempty,min=1,max=200"`
+	Description *string `json:"description" binding:"omitempty,max=1000"`
+}
+
+// SetSeriesPostsReq 设置（或重排）系列文章请求体。
+type SetSeriesPostsReq struct {
+	PostIds []uint `json:"post_ids" binding:"required"`
+}
+
+// SeriesPartResp 系列中的一篇文章
+type SeriesPartResp struct {
+	Position int    `json:"position"`
+	PostId   uint   `json:"post_id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+}
+
+// SeriesResp 系列响应
+type SeriesResp struct {
+	Id          uint             `json:"id"`
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	User        UserBrief        `json:"user"`
+	Parts       []SeriesPartResp `json:"parts,omitempty"`
+	Trashed     []SeriesPartResp `json:"trashed_parts,omitempty"` // 已移入回收站的篇目（仅作者可见，不占位次）
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+// TrashPostResp 回收站中的文章
+type TrashPostResp struct {
+	Id        uint      `json:"id"`
+	Title     string    `json:"title"`
+	Status    string    `json:"status"`
+	UserId    uint      `json:"user_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+	PurgeAt   time.Time `json:"purge_at"`
+}
+
+// TrashCommentResp 回收站中的评论
+type TrashCommentResp struct {
+	Id        uint      `json:"id"`
+	Content   string    `json:"content"`
+	PostId    uint      `json:"post_id"`
+	UserId    uint      `json:"user_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+	PurgeAt   time.Time `json:"purge_at"`
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 // CreateUserReq 用于注册用户的请求体
 type CreateUserReq struct {
 	Username string `json:"username" binding:"required,min=3,max=32"`
@@ -18,3 +20,47 @@ type UpdateUserReq struct {
 	Email    *string `json:"email"    binding:"omitempty,email"`
 	Password *string `json:"password" binding:"omitempty,min=6,max=64"`
 }
+
+// UserExport 个人数据导出：资料、文章、评论、收藏与阅读清单。
+type UserExport struct {
+	ExportedAt   time.Time         `json:"exported_at"`
+	Profile      ExportProfile     `json:"profile"`
+	Posts        []ExportPost      `json:"posts"`
+	Comments     []ExportComment   `json:"comments"`
+	Bookmarks    []BookmarkResp    `json:"bookmarks"`
+	ReadingLists []ReadingListResp `json:"reading_lists"`
+}
+
+// ExportProfile 导出的用户资料。
+type ExportProfile struct {
+	Id        uint      `json:"id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ExportPost 导出的文章。
+type ExportPost struct {
+	Id        uint      `json:"id"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ExportComment 导出的评论。
+type ExportComment struct {
+	Id        uint      `json:"id"`
+	PostId    uint      `json:"post_id"`
+	ParentId  *uint     `json:"parent_id,omitempty"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BlockedUserResp 屏蔽列表中的用户
+type BlockedUserResp struct {
+	User      UserBrief `json:"user"`
+	CreatedAt time.Time `json:"created_at"` // 屏蔽时间
+}
@@ -0,0 +1,118 @@
+package export
+
+import (
+	"html"
+	"html/template"
+	"regexp"
+	"strings"
+)
+
+// RenderContent 将文章正文转为 HTML：以 "<" 开头的正文（如从 WordPress 导入）视为 HTML，经 SanitizeHTML 清理后输出，
+// 其余按 Markdown 的常用子集渲染（标题、段落、围栏代码块、列表、引用、图片、链接、行内代码）。
+func RenderContent(content string) template.HTML {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	if strings.HasPrefix(strings.TrimSpace(content), "<") {
+		return template.HTML(SanitizeHTML(content))
+	}
+
+	var b strings.Builder
+	var para []string
+	flush := func() {
+		if len(para) > 0 {
+			b.WriteString("<p>" + strings.Join(para, "<br>\n") + "</p>\n")
+			para = nil
+		}
+	}
+
+	lines := strings.Split(content, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			flush()
+		case strings.HasPrefix(trimmed, "```"):
+			flush()
+			lang := strings.TrimSpace(strings.TrimPrefix(trimmed, "```"))
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			class := ""
+			if lang != "" {
+				class = ` class="language-` + html.EscapeString(lang) + `"`
+			}
+			b.WriteString("<pre><code" + class + ">" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+		case headingRe.MatchString(trimmed):
+			flush()
+			m := headingRe.FindStringSubmatch(trimmed)
+			level := string(rune('0' + len(m[1])))
+			b.WriteString("<h" + level + ">" + renderInline(m[2]) + "</h" + level + ">\n")
+		case listRe.MatchString(trimmed):
+			flush()
+			b.WriteString("<ul>\n")
+			for ; i < len(lines) && listRe.MatchString(strings.TrimSpace(lines[i])); i++ {
+				item := listRe.ReplaceAllString(strings.TrimSpace(lines[i]), "")
+				b.WriteString("<li>" + renderInline(item) + "</li>\n")
+			}
+			i--
+			b.WriteString("</ul>\n")
+		case strings.HasPrefix(trimmed, ">"):
+			flush()
+			var quote []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				quote = append(quote, renderInline(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(lines[i]), ">"))))
+			}
+			i--
+			b.WriteString("<blockquote><p>" + strings.Join(quote, "<br>\n") + "</p></blockquote>\n")
+		default:
+			para = append(para, renderInline(trimmed))
+		}
+	}
+	flush()
+	return template.HTML(b.String())
+}
+
+var (
+	headingRe = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	listRe    = regexp.MustCompile(`^([-*+]|\d+\.)\s+`)
+	codeRe    = regexp.MustCompile("`([^`]+)`")
+	imageRe   = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)(?:\s+&#34;[^)]*&#34;)?\)`)
+	linkRe    = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)(?:\s+&#34;[^)]*&#34;)?\)`)
+	strongRe  = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+)
+
+// renderInline 渲染行内元素；先整体转义，再替换标记，因此链接地址等同样经过转义。
+func renderInline(s string) string {
+	s = html.EscapeString(s)
+	s = codeRe.ReplaceAllString(s, "<code>$1</code>")
+	s = imageRe.ReplaceAllStringFunc(s, func(m string) string {
+		sub := imageRe.FindStringSubmatch(m)
+		return `<img src="` + safeURL(sub[2]) + `" alt="` + sub[1] + `">`
+	})
+	s = linkRe.ReplaceAllStringFunc(s, func(m string) string {
+		sub := linkRe.FindStringSubmatch(m)
+		return `<a href="` + safeURL(sub[2]) + `">` + sub[1] + `</a>`
+	})
+	return strongRe.ReplaceAllString(s, "<strong>$1</strong>")
+}
+
+// safeURL 与 HTML 正文使用同一白名单（allowedURL）；u 已经过转义，判断前先还原，不允许的地址替换为 "#"。
+func safeURL(u string) string {
+	if !allowedURL(html.UnescapeString(u)) {
+		return "#"
+	}
+	return u
+}
+
+// Excerpt 生成纯文本摘要（最多 n 个字符）。
+func Excerpt(content string, n int) string {
+	text := tagRe.ReplaceAllString(content, " ")
+	text = strings.Join(strings.Fields(html.UnescapeString(text)), " ")
+	if r := []rune(text); len(r) > n {
+		return string(r[:n]) + "…"
+	}
+	return text
+}
+
+var tagRe = regexp.MustCompile(`<[^>]*>|!?\[|\]\([^)]*\)|[#*` + "`" + `>]`)
@@ -0,0 +1,46 @@
+package export
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderContentMarkdownLinks(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"link", `[site](https://example.com)`, `<p><a href="https://example.com">site</a></p>`},
+		{"relative link", `[post](/posts/1)`, `<p><a href="/posts/1">post</a></p>`},
+		{"mailto link", `[mail](mailto:a@example.com)`, `<p><a href="mailto:a@example.com">mail</a></p>`},
+		{"link query escaped", `[q](https://example.com/?a=1&b=2)`, `<p><a href="https://example.com/?a=1&amp;b=2">q</a></p>`},
+		{"javascript link", `[x](javascript:alert(1))`, `<p><a href="#">x</a>)</p>`},
+		{"javascript link mixed case", `[x](JavaScript:alert)`, `<p><a href="#">x</a></p>`},
+		{"entity in link stays literal", `[x](&#106;avascript:alert)`, `<p><a href="&amp;#106;avascript:alert">x</a></p>`},
+		{"vbscript link", `[x](vbscript:msgbox)`, `<p><a href="#">x</a></p>`},
+		{"data link", `[x](data:text/html;base64,PHNjcmlwdD4=)`, `<p><a href="#">x</a></p>`},
+		{"image", `![cat](/img/cat.png)`, `<p><img src="/img/cat.png" alt="cat"></p>`},
+		{"javascript image", `![x](javascript:alert)`, `<p><img src="#" alt="x"></p>`},
+		{"data image", `![x](data:image/svg+xml;base64,AAAA)`, `<p><img src="#" alt="x"></p>`},
+		{"alt escaped", `![<b>](/a.png)`, `<p><img src="/a.png" alt="&lt;b&gt;"></p>`},
+		{"raw html escaped", `hello <script>alert(1)</script>`, `<p>hello &lt;script&gt;alert(1)&lt;/script&gt;</p>`},
+		{"code fence escaped", "```go\n<b>x</b>\n```", `<pre><code class="language-go">&lt;b&gt;x&lt;/b&gt;</code></pre>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.TrimSpace(string(RenderContent(tt.in)))
+			if got != tt.want {
+				t.Errorf("RenderContent(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderContentHTMLIsSanitized(t *testing.T) {
+	got := string(RenderContent(`<p onclick="x()">hi<script>alert(1)</script> <a href="javascript:alert(1)">x</a>`))
+	want := `<p>hi <a>x</a></p>`
+	if got != want {
+		t.Fatalf("RenderContent = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,369 @@
+package export
+
+import (
+	"embed"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+// feedSize RSS 中包含的最新文章数。
+const feedSize = 20
+
+// Stats 导出统计。
+type Stats struct {
+	Pages  int `json:"pages"`  // 生成的 HTML 页面数
+	Posts  int `json:"posts"`  // 导出的文章数
+	Assets int `json:"assets"` // 复制的上传文件数
+}
+
+// renderer 一次导出的渲染上下文。
+type renderer struct {
+	site  *Site
+	dir   string
+	tmpl  *template.Template
+	stats Stats
+}
+
+// page 模板数据。
+type page struct {
+	Site   *Site
+	Title  string
+	Posts  []*Post
+	Post   *Post
+	Prev   string
+	Next   string
+	Kind   string
+	Terms  []termCount
+	Months []archiveMonth
+}
+
+type termCount struct {
+	Term  Term
+	Count int
+}
+
+type archiveMonth struct {
+	Label string
+	Posts []*Post
+}
+
+// Render 将站点渲染到 outDir，并把 assetsDir（上传目录）复制到 outDir/static/uploads。
+// 先写入同级临时目录，全部成功后再替换 outDir，导出失败时不会留下不完整的站点。
+func Render(outDir, assetsDir string, site *Site) (*Stats, error) {
+	if site.PageSize <= 0 {
+		site.PageSize = 10
+	}
+	if site.GeneratedAt.IsZero() {
+		site.GeneratedAt = time.Now()
+	}
+
+	outDir = filepath.Clean(outDir)
+	if err := os.MkdirAll(filepath.Dir(outDir), 0o755); err != nil {
+		return nil, err
+	}
+	staging, err := os.MkdirTemp(filepath.Dir(outDir), filepath.Base(outDir)+".tmp-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(staging)
+
+	r := &renderer{site: site, dir: staging}
+	if r.tmpl, err = template.New("").Funcs(r.funcs()).ParseFS(templateFS, "templates/*.html"); err != nil {
+		return nil, err
+	}
+	steps := []func() error{
+		r.renderIndex,
+		r.renderPosts,
+		func() error { return r.renderTerms("categories", "分类", site.Categories, postCategories) },
+		func() error {
+			return r.renderTerms("tags", "标签", site.Tags, func(p *Post) []Term { return p.Tags })
+		},
+		r.renderArchive,
+		r.renderFeed,
+		func() error { return r.copyAssets(assetsDir) },
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+	r.stats.Posts = len(site.Posts)
+
+	// 替换旧目录：先移走旧目录再改名，最后删除旧目录
+	old := ""
+	if _, err := os.Stat(outDir); err == nil {
+		old = staging + ".old"
+		if err := os.Rename(outDir, old); err != nil {
+			return nil, err
+		}
+	}
+	if err := os.Rename(staging, outDir); err != nil {
+		if old != "" {
+			_ = os.Rename(old, outDir)
+		}
+		return nil, err
+	}
+	if old != "" {
+		_ = os.RemoveAll(old)
+	}
+	return &r.stats, nil
+}
+
+func (r *renderer) funcs() template.FuncMap {
+	return template.FuncMap{
+		"root":    r.site.rootURL,
+		"postURL": r.site.postURL,
+		"termURL": r.site.termURL,
+		"content": func(s string) template.HTML { return r.content(s, r.site.rootURL(uploadsPath)) },
+		"excerpt": func(s string) string { return Excerpt(s, 160) },
+		"date":    func(t time.Time) string { return t.Format("2006-01-02") },
+		"indent": func(depth int) template.CSS {
+			if depth > 6 {
+				depth = 6
+			}
+			return template.CSS(fmt.Sprintf("margin-left:%.1frem", float64(depth)*1.5))
+		},
+	}
+}
+
+// uploadsPath 正文中上传文件的访问路径前缀（与线上一致）。
+const uploadsPath = "/static/uploads/"
+
+// content 渲染正文，并将上传文件地址改写到 prefix 下（子路径部署或 RSS 绝对地址）。
+func (r *renderer) content(s, prefix string) template.HTML {
+	out := string(RenderContent(s))
+	if prefix != uploadsPath {
+		out = strings.ReplaceAll(out, `"`+uploadsPath, `"`+prefix)
+	}
+	return template.HTML(out)
+}
+
+// write 渲染模板到 rel 目录下的 index.html（rel 为站内路径，如 /posts/1/）。
+func (r *renderer) write(rel, name string, data page) error {
+	data.Site = r.site
+	dst := filepath.Join(r.dir, filepath.FromSlash(strings.Trim(rel, "/")), "index.html")
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := r.tmpl.ExecuteTemplate(f, name, data); err != nil {
+		return fmt.Errorf("render %s: %w", rel, err)
+	}
+	r.stats.Pages++
+	return nil
+}
+
+// renderIndex 首页及分页。
+func (r *renderer) renderIndex() error {
+	posts := r.site.Posts
+	pages := (len(posts) + r.site.PageSize - 1) / r.site.PageSize
+	if pages == 0 {
+		pages = 1
+	}
+	for n := 1; n <= pages; n++ {
+		start := (n - 1) * r.site.PageSize
+		end := start + r.site.PageSize
+		if end > len(posts) {
+			end = len(posts)
+		}
+		data := page{Posts: posts[start:end]}
+		if n > 1 {
+			data.Title = fmt.Sprintf("第 %d 页", n)
+			data.Prev = r.site.rootURL(pagePath(n - 1))
+		}
+		if n < pages {
+			data.Next = r.site.rootURL(pagePath(n + 1))
+		}
+		if err := r.write(pagePath(n), "index.html", data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderPosts 文章详情页。
+func (r *renderer) renderPosts() error {
+	for _, p := range r.site.Posts {
+		rel := strings.TrimPrefix(r.site.postURL(p), r.site.basePath())
+		if err := r.write(rel, "post.html", page{Title: p.Title, Post: p}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderTerms 分类/标签索引页及各自的文章列表页（没有文章的项不生成）。
+func (r *renderer) renderTerms(kind, label string, terms []Term, of func(*Post) []Term) error {
+	bySlug := make(map[string][]*Post)
+	for _, p := range r.site.Posts {
+		for _, t := range of(p) {
+			bySlug[t.Slug] = append(bySlug[t.Slug], p)
+		}
+	}
+	var index []termCount
+	for _, t := range terms {
+		posts := bySlug[t.Slug]
+		if len(posts) == 0 || !safeSegment(t.Slug) {
+			continue
+		}
+		index = append(index, termCount{Term: t, Count: len(posts)})
+		rel := "/" + kind + "/" + t.Slug + "/"
+		if err := r.write(rel, "list.html", page{Title: label + "：" + t.Name, Posts: posts}); err != nil {
+			return err
+		}
+	}
+	return r.write("/"+kind+"/", "terms.html", page{Title: label, Kind: kind, Terms: index})
+}
+
+// renderArchive 按年月分组的归档页。
+func (r *renderer) renderArchive() error {
+	var months []archiveMonth
+	for _, p := range r.site.Posts {
+		label := p.CreatedAt.Format("2006 年 01 月")
+		if len(months) == 0 || months[len(months)-1].Label != label {
+			months = append(months, archiveMonth{Label: label})
+		}
+		months[len(months)-1].Posts = append(months[len(months)-1].Posts, p)
+	}
+	return r.write("/archive/", "archive.html", page{Title: "归档", Months: months})
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        string   `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Categories  []string `xml:"category"`
+	Description string   `xml:"description"`
+}
+
+// renderFeed 生成 RSS 2.0（最新 feedSize 篇）。
+func (r *renderer) renderFeed() error {
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         r.site.Title,
+			Link:          r.site.absURL(r.site.rootURL("/")),
+			Description:   r.site.Title,
+			LastBuildDate: r.site.GeneratedAt.Format(time.RFC1123Z),
+		},
+	}
+	for i, p := range r.site.Posts {
+		if i == feedSize {
+			break
+		}
+		link := r.site.absURL(r.site.postURL(p))
+		item := rssItem{
+			Title:       p.Title,
+			Link:        link,
+			GUID:        link,
+			PubDate:     p.CreatedAt.Format(time.RFC1123Z),
+			Description: string(r.content(p.Content, r.site.absURL(r.site.rootURL(uploadsPath)))),
+		}
+		if p.Category != nil {
+			item.Categories = append(item.Categories, p.Category.Name)
+		}
+		feed.Channel.Items = append(feed.Channel.Items, item)
+	}
+
+	f, err := os.Create(filepath.Join(r.dir, "feed.xml"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := io.WriteString(f, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(f)
+	enc.Indent("", "  ")
+	return enc.Encode(feed)
+}
+
+// copyAssets 复制上传目录到 static/uploads，保持与线上相同的访问路径。
+func (r *renderer) copyAssets(src string) error {
+	if src == "" {
+		return nil
+	}
+	if _, err := os.Stat(src); os.IsNotExist(err) {
+		return nil
+	}
+	dstRoot := filepath.Join(r.dir, "static", "uploads")
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(dstRoot, rel)
+		if d.IsDir() {
+			return os.MkdirAll(dst, 0o755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if err := copyFile(path, dst); err != nil {
+			return err
+		}
+		r.stats.Assets++
+		return nil
+	})
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func postCategories(p *Post) []Term {
+	if p.Category == nil {
+		return nil
+	}
+	return []Term{*p.Category}
+}
+
+// safeSegment slug 作为目录名时不能包含路径分隔符或上级目录。
+func safeSegment(s string) bool {
+	return s != "" && s != "." && s != ".." && !strings.ContainsAny(s, `/\`)
+}
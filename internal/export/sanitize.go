@@ -0,0 +1,142 @@
+package export
+
+import (
+	"html"
+	"strings"
+
+	xhtml "golang.org/x/net/html"
+)
+
+// allowedTags 允许保留的标签及其专属属性；不在表中的标签去掉标签本身、保留文本。
+var allowedTags = map[string][]string{
+	"p": nil, "br": nil, "hr": nil, "div": nil, "span": nil,
+	"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+	"strong": nil, "b": nil, "em": nil, "i": nil, "u": nil, "s": nil, "del": nil, "ins": nil,
+	"sub": nil, "sup": nil, "small": nil, "mark": nil, "abbr": nil, "cite": nil, "q": nil,
+	"blockquote": nil, "pre": nil, "code": nil, "kbd": nil,
+	"ul": nil, "ol": {"start"}, "li": nil, "dl": nil, "dt": nil, "dd": nil,
+	"a":      {"href", "rel"},
+	"img":    {"src", "alt", "width", "height"},
+	"figure": nil, "figcaption": nil,
+	"table": nil, "caption": nil, "thead": nil, "tbody": nil, "tfoot": nil, "tr": nil,
+	"th": {"colspan", "rowspan", "align"},
+	"td": {"colspan", "rowspan", "align"},
+}
+
+// globalAttrs 所有允许的标签都可携带的属性。
+var globalAttrs = []string{"title", "class"}
+
+// droppedTags 连同内容一起丢弃的标签。
+var droppedTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true,
+	"noscript": true, "template": true, "textarea": true, "select": true, "svg": true, "math": true,
+}
+
+// voidTags 没有结束标签的元素。
+var voidTags = map[string]bool{"br": true, "hr": true, "img": true}
+
+// SanitizeHTML 按白名单清理 HTML 正文：只保留常见排版标签与属性，丢弃脚本、样式、
+// 内嵌框架及 on* 事件属性，链接与图片地址只允许 http、https、mailto 或相对地址；未闭合的标签在末尾补齐。
+func SanitizeHTML(s string) string {
+	var b strings.Builder
+	var open []string
+	skip := 0 // 位于被丢弃标签内部的层数
+
+	z := xhtml.NewTokenizer(strings.NewReader(s))
+	for {
+		tt := z.Next()
+		if tt == xhtml.ErrorToken {
+			break
+		}
+		tok := z.Token()
+		name := tok.Data
+		switch tt {
+		case xhtml.StartTagToken, xhtml.SelfClosingTagToken:
+			if droppedTags[name] {
+				if tt == xhtml.StartTagToken {
+					skip++
+				}
+				continue
+			}
+			attrs, ok := allowedTags[name]
+			if !ok || skip > 0 {
+				continue
+			}
+			b.WriteString("<" + name)
+			for _, a := range tok.Attr {
+				if a.Namespace != "" || !allowedAttr(a.Key, attrs) {
+					continue
+				}
+				if (a.Key == "href" || a.Key == "src") && !allowedURL(a.Val) {
+					continue
+				}
+				b.WriteString(" " + a.Key + `="` + html.EscapeString(a.Val) + `"`)
+			}
+			b.WriteString(">")
+			if tt == xhtml.StartTagToken && !voidTags[name] {
+				open = append(open, name)
+			}
+		case xhtml.EndTagToken:
+			if droppedTags[name] {
+				if skip > 0 {
+					skip--
+				}
+				continue
+			}
+			if skip > 0 {
+				continue
+			}
+			// 只关闭已打开的标签，中间未闭合的一并补齐
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] != name {
+					continue
+				}
+				for j := len(open) - 1; j >= i; j-- {
+					b.WriteString("</" + open[j] + ">")
+				}
+				open = open[:i]
+				break
+			}
+		case xhtml.TextToken:
+			if skip == 0 {
+				b.WriteString(html.EscapeString(tok.Data))
+			}
+		}
+	}
+	for i := len(open) - 1; i >= 0; i-- {
+		b.WriteString("</" + open[i] + ">")
+	}
+	return b.String()
+}
+
+// allowedAttr 判断属性是否在全局或标签专属白名单中（on* 等事件属性均不在其中）。
+func allowedAttr(key string, tagAttrs []string) bool {
+	for _, list := range [][]string{globalAttrs, tagAttrs} {
+		for _, a := range list {
+			if a == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// allowedURL 只允许 http、https、mailto 协议或相对地址；先去掉空白与控制字符，防止 "java\tscript:" 之类的绕过。
+func allowedURL(u string) bool {
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, u)
+	cleaned = strings.ToLower(cleaned)
+	i := strings.IndexAny(cleaned, ":/?#")
+	if i < 0 || cleaned[i] != ':' {
+		return true // 相对地址
+	}
+	switch cleaned[:i] {
+	case "http", "https", "mailto":
+		return true
+	}
+	return false
+}
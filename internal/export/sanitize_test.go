@@ -0,0 +1,75 @@
+package export
+
+import "testing"
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain markup", `<p>hi <strong>there</strong></p>`, `<p>hi <strong>there</strong></p>`},
+		{"script dropped", `<p>a</p><script>alert(1)</script><p>b</p>`, `<p>a</p><p>b</p>`},
+		{"style dropped", `<style>p{color:red}</style><p>x</p>`, `<p>x</p>`},
+		{"iframe dropped", `<iframe src="https://evil.example"><p>inside</p></iframe>ok`, `ok`},
+		{"nested dropped", `<svg><script>x</script><p>y</p></svg>z`, `z`},
+		{"unknown tag keeps text", `<custom>text</custom>`, `text`},
+		{"event attributes", `<p onclick="x()" class="c" onmouseover="y()">t</p>`, `<p class="c">t</p>`},
+		{"img onerror", `<img src="a.png" onerror="alert(1)" alt="a">`, `<img src="a.png" alt="a">`},
+		{"style attribute", `<span style="background:url(x)">s</span>`, `<span>s</span>`},
+		{"javascript href", `<a href="javascript:alert(1)">x</a>`, `<a>x</a>`},
+		{"javascript upper case", `<a href="JaVaScRiPt:alert(1)">x</a>`, `<a>x</a>`},
+		{"javascript entities", `<a href="&#106;avascript:alert(1)">x</a>`, `<a>x</a>`},
+		{"javascript hex entities", `<a href="&#x6A;&#x61;vascript:alert(1)">x</a>`, `<a>x</a>`},
+		{"javascript tab", "<a href=\"java\tscript:alert(1)\">x</a>", `<a>x</a>`},
+		{"javascript entity tab", `<a href="java&#9;script:alert(1)">x</a>`, `<a>x</a>`},
+		{"javascript control char", "<a href=\"\x01javascript:alert(1)\">x</a>", `<a>x</a>`},
+		{"javascript leading space", `<a href="  javascript:alert(1)">x</a>`, `<a>x</a>`},
+		{"data image", `<img src="data:image/png;base64,AAAA">`, `<img>`},
+		{"vbscript", `<a href="vbscript:msgbox">x</a>`, `<a>x</a>`},
+		{"https kept", `<a href="https://example.com/?a=1&b=2">x</a>`, `<a href="https://example.com/?a=1&amp;b=2">x</a>`},
+		{"mailto kept", `<a href="mailto:a@example.com">x</a>`, `<a href="mailto:a@example.com">x</a>`},
+		{"relative kept", `<a href="/posts/1#c">x</a>`, `<a href="/posts/1#c">x</a>`},
+		{"colon after slash is relative", `<a href="/a:b">x</a>`, `<a href="/a:b">x</a>`},
+		{"unclosed tags closed", `<div><p><em>x`, `<div><p><em>x</em></p></div>`},
+		{"intermediate tags closed", `<div><p>x</div>y`, `<div><p>x</p></div>y`},
+		{"stray end tag", `x</p></div>`, `x`},
+		{"unclosed script drops rest", `<p>a</p><script>alert(1)`, `<p>a</p>`},
+		{"text escaped", `a < b & "c"`, `a &lt; b &amp; &#34;c&#34;`},
+		{"attribute escaped", `<abbr title="&quot;><script>">x</abbr>`, `<abbr title="&#34;&gt;&lt;script&gt;">x</abbr>`},
+		{"void embed keeps rest", `<embed src="x.swf"><p>after</p>`, `<p>after</p>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeHTML(tt.in); got != tt.want {
+				t.Errorf("SanitizeHTML(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllowedURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://example.com", true},
+		{"HTTP://example.com", true},
+		{"mailto:a@example.com", true},
+		{"/relative/path", true},
+		{"image.png", true},
+		{"?q=1", true},
+		{"#top", true},
+		{"javascript:alert(1)", false},
+		{"java\nscript:alert(1)", false},
+		{"java\x00script:alert(1)", false},
+		{"\x7fjavascript:alert(1)", false},
+		{"data:text/html,<script>", false},
+		{"ftp://example.com", false},
+	}
+	for _, tt := range tests {
+		if got := allowedURL(tt.url); got != tt.want {
+			t.Errorf("allowedURL(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Package export 将博客内容渲染为静态 HTML 站点（只负责渲染与写文件，不访问数据库）。
+package export
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Site 待导出的站点数据。
+type Site struct {
+	Title       string
+	BaseURL     string // 站点地址，如 https://blog.example.com/blog；用于 feed 中的绝对链接及页面路径前缀
+	PageSize    int    // 首页每页文章数
+	GeneratedAt time.Time
+	Posts       []*Post // 按时间倒序
+	Categories  []Term
+	Tags        []Term
+}
+
+// Post 一篇已发布的公开文章。
+type Post struct {
+	ID        uint
+	Title     string
+	Author    string
+	Content   string
+	Category  *Term
+	Tags      []Term
+	Comments  []Comment
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Term 分类或标签。
+type Term struct {
+	Name string
+	Slug string
+}
+
+// Comment 文章下的评论（按会话顺序排列，Depth 为回复层级）。
+type Comment struct {
+	ID        uint
+	Author    string
+	Content   string
+	Depth     int
+	CreatedAt time.Time
+}
+
+// basePath 返回 BaseURL 中的路径部分（不含末尾斜杠），站点部署在子路径时用作链接前缀。
+func (s *Site) basePath() string {
+	u, err := url.Parse(s.BaseURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(u.Path, "/")
+}
+
+// absURL 将站内路径转换为绝对地址；未配置 BaseURL 时返回站内路径。
+func (s *Site) absURL(p string) string {
+	u, err := url.Parse(s.BaseURL)
+	if err != nil || u.Host == "" {
+		return p
+	}
+	return strings.TrimSuffix(u.Scheme+"://"+u.Host, "/") + p
+}
+
+// rootURL 为站内路径加上 basePath 前缀。
+func (s *Site) rootURL(p string) string {
+	return s.basePath() + p
+}
+
+// postURL 文章页的站内路径。
+func (s *Site) postURL(p *Post) string {
+	return s.basePath() + "/posts/" + strconv.FormatUint(uint64(p.ID), 10) + "/"
+}
+
+// termURL 分类或标签页的站内路径，kind 为 "categories" 或 "tags"。
+func (s *Site) termURL(kind string, t Term) string {
+	return s.basePath() + "/" + kind + "/" + url.PathEscape(t.Slug) + "/"
+}
+
+// pagePath 首页第 n 页的相对路径。
+func pagePath(n int) string {
+	if n <= 1 {
+		return "/"
+	}
+	return "/page/" + strconv.Itoa(n) + "/"
+}
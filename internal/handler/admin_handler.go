@@ -120,6 +120,7 @@ func (h *AdminHandler) ListComments(c *gin.Context) {
 		Page:     page,
 		PageSize: pageSize,
 		Keyword:  c.Query("keyword"),
+		Status:   c.Query("status"),
 	}
 
 	if uidStr := c.Query("user_id"); uidStr != "" {
@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"go-blog/internal/dto"
+	"go-blog/internal/middleware"
+	"go-blog/internal/service"
+	"go-blog/internal/util"
+)
+
+// BookmarkHandler 处理收藏与阅读清单相关 HTTP 请求。
+type BookmarkHandler struct{ svc *service.BookmarkService }
+
+func NewBookmarkHandler(svc *service.BookmarkService) *BookmarkHandler {
+	return &BookmarkHandler{svc: svc}
+}
+
+// AddBookmark 收藏文章：POST /api/posts/:id/bookmark
+func (h *BookmarkHandler) AddBookmark(c *gin.Context) {
+	postID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req dto.AddBookmarkReq
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"code":    400,
+				"message": "参数错误",
+				"detail":  err.Error(),
+			})
+			return
+		}
+	}
+
+	b, err := h.svc.AddBookmark(c.Request.Context(), middleware.UID(c), postID, req)
+	if err != nil {
+		h.renderError(c, err, "收藏失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "收藏成功",
+		"data": gin.H{
+			"id":              b.Id,
+			"post_id":         b.PostId,
+			"reading_list_id": b.ReadingListId,
+		},
+	})
+}
+
+// RemoveBookmark 取消收藏：DELETE /api/posts/:id/bookmark
+func (h *BookmarkHandler) RemoveBookmark(c *gin.Context) {
+	postID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.svc.RemoveBookmark(c.Request.Context(), middleware.UID(c), postID); err != nil {
+		h.renderError(c, err, "取消收藏失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "取消收藏成功",
+	})
+}
+
+// ListBookmarks 分页查询我的收藏：GET /api/me/bookmarks?list_id=
+func (h *BookmarkHandler) ListBookmarks(c *gin.Context) {
+	page, pageSize := util.ParsePage(c)
+	var listID *uint
+	if lidStr := c.Query("list_id"); lidStr != "" {
+		if lid64, err := strconv.ParseUint(lidStr, 10, 64); err == nil && lid64 > 0 {
+			lid := uint(lid64)
+			listID = &lid
+		}
+	}
+
+	bookmarks, total, err := h.svc.ListBookmarks(c.Request.Context(), middleware.UID(c), listID, page, pageSize)
+	if err != nil {
+		h.renderError(c, err, "查询收藏失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code": 0,
+		"data": util.PageResult{
+			Page:     page,
+			PageSize: pageSize,
+			Total:    total,
+			List:     service.ToBookmarkResps(bookmarks),
+		},
+	})
+}
+
+// ListReadingLists 我的阅读清单：GET /api/me/reading-lists
+func (h *BookmarkHandler) ListReadingLists(c *gin.Context) {
+	lists, err := h.svc.ListReadingLists(c.Request.Context(), middleware.UID(c))
+	if err != nil {
+		h.renderError(c, err, "查询阅读清单失败")
+		return
+	}
+	resp := make([]dto.ReadingListResp, 0, len(lists))
+	for _, l := range lists {
+		resp = append(resp, service.ToReadingListResp(l))
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code": 0,
+		"data": resp,
+	})
+}
+
+// CreateReadingList 创建阅读清单：POST /api/me/reading-lists
+func (h *BookmarkHandler) CreateReadingList(c *gin.Context) {
+	var req dto.CreateReadingListReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "参数错误",
+			"detail":  err.Error(),
+		})
+		return
+	}
+	l, err := h.svc.CreateReadingList(c.Request.Context(), middleware.UID(c), req)
+	if err != nil {
+		h.renderError(c, err, "创建阅读清单失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "创建成功",
+		"data":    service.ToReadingListResp(*l),
+	})
+}
+
+// UpdateReadingList 更新阅读清单：PUT /api/me/reading-lists/:id
+func (h *BookmarkHandler) UpdateReadingList(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req dto.UpdateReadingListReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "参数错误",
+			"detail":  err.Error(),
+		})
+		return
+	}
+	l, err := h.svc.UpdateReadingList(c.Request.Context(), middleware.UID(c), id, req)
+	if err != nil {
+		h.renderError(c, err, "更新阅读清单失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "更新成功",
+		"data":    service.ToReadingListResp(*l),
+	})
+}
+
+// DeleteReadingList 删除阅读清单：DELETE /api/me/reading-lists/:id
+func (h *BookmarkHandler) DeleteReadingList(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.svc.DeleteReadingList(c.Request.Context(), middleware.UID(c), id); err != nil {
+		h.renderError(c, err, "删除阅读清单失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "删除成功",
+	})
+}
+
+// SharedReadingList 通过分享链接查看公开阅读清单（无需登录）：GET /api/shared/reading-lists/:token
+func (h *BookmarkHandler) SharedReadingList(c *gin.Context) {
+	page, pageSize := util.ParsePage(c)
+	l, bookmarks, total, err := h.svc.GetSharedReadingList(c.Request.Context(), c.Param("token"), page, pageSize)
+	if err != nil {
+		h.renderError(c, err, "查询阅读清单失败")
+		return
+	}
+	resp := service.ToReadingListResp(*l)
+	c.JSON(http.StatusOK, gin.H{
+		"code": 0,
+		"data": gin.H{
+			"reading_list": resp,
+			"bookmarks": util.PageResult{
+				Page:     page,
+				PageSize: pageSize,
+				Total:    total,
+				List:     service.ToBookmarkResps(bookmarks),
+			},
+		},
+	})
+}
+
+func (h *BookmarkHandler) renderError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrPostNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "文章不存在"})
+	case errors.Is(err, service.ErrBookmarkNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "收藏不存在"})
+	case errors.Is(err, service.ErrReadingListNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "阅读清单不存在"})
+	case errors.Is(err, service.ErrReadingListForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "无权操作该阅读清单"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": message,
+			"detail":  err.Error(),
+		})
+	}
+}
@@ -10,14 +10,28 @@ import (
 
 	"go-blog/internal/dto"
 	"go-blog/internal/middleware"
+	"go-blog/internal/model"
 	"go-blog/internal/service"
 	"go-blog/internal/util"
 )
 
+// maxPreviewReplies 每条顶层评论最多预加载的回复数。
+const maxPreviewReplies = 20
+
 // CommentHandler 处理评论相关 HTTP 接口。
-type CommentHandler struct{ svc *service.CommentService }
+type CommentHandler struct {
+	svc            *service.CommentService
+	previewReplies int
+}
 
-func NewCommentHandler(svc *service.CommentService) *CommentHandler { return &CommentHandler{svc: svc} }
+// NewCommentHandler 构造评论处理器，默认预加载回复数由 COMMENT_PREVIEW_REPLIES 配置。
+func NewCommentHandler(svc *service.CommentService) *CommentHandler {
+	n := util.EnvInt("COMMENT_PREVIEW_REPLIES", 3)
+	if n < 0 || n > maxPreviewReplies {
+		n = 3
+	}
+	return &CommentHandler{svc: svc, previewReplies: n}
+}
 
 // CreateComment 创建评论
 func (h *CommentHandler) CreateComment(c *gin.Context) {
@@ -31,8 +45,10 @@ func (h *CommentHandler) CreateComment(c *gin.Context) {
 		return
 	}
 	uid := middleware.UID(c)
+	req.IP, req.UserAgent = c.ClientIP(), c.Request.UserAgent()
+	req.Password = postPassword(c)
 
-	comment, err := h.svc.CreateComment(c.Request.Context(), uid, req)
+	comment, err := h.svc.CreateComment(c.Request.Context(), uid, middleware.Role(c), req)
 	if err != nil {
 		switch {
 		case errors.Is(err, service.ErrPostMissing):
@@ -41,6 +57,8 @@ func (h *CommentHandler) CreateComment(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "父评论不存在"})
 		case errors.Is(err, service.ErrParentMismatch):
 			c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "父评论不属于当前文章"})
+		case errors.Is(err, service.ErrPostPasswordRequired):
+			c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "文章受密码保护，请提供正确密码"})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"code":    500,
@@ -52,19 +70,21 @@ func (h *CommentHandler) CreateComment(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, gin.H{
 		"code":    0,
-		"message": "创建评论成功",
+		"message": commentCreatedMessage(comment, "创建评论成功"),
 		"data": gin.H{
 			"id":         comment.Id,
 			"content":    comment.Content,
 			"user_id":    comment.UserId,
 			"post_id":    comment.PostId,
 			"parent_id":  comment.ParentId,
+			"status":     comment.Status,
+			"mentions":   comment.Mentions,
 			"created_at": comment.CreatedAt.Format(time.RFC3339),
 		},
 	})
 }
 
-// DeleteComment 删除评论：仅评论作者本人可删除。
+// DeleteComment 删除评论：仅评论作者本人可删除；有回复的评论保留为占位（data.tombstone=true）。
 func (h *CommentHandler) DeleteComment(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 64)
@@ -78,7 +98,8 @@ func (h *CommentHandler) DeleteComment(c *gin.Context) {
 	}
 
 	uid := middleware.UID(c)
-	if err := h.svc.DeleteComment(c.Request.Context(), uid, uint(id)); err != nil {
+	tombstoned, err := h.svc.DeleteComment(c.Request.Context(), uid, uint(id))
+	if err != nil {
 		switch {
 		case errors.Is(err, service.ErrCommentNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "评论不存在"})
@@ -96,6 +117,81 @@ func (h *CommentHandler) DeleteComment(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"code":    0,
 		"message": "删除评论成功",
+		"data":    gin.H{"tombstone": tombstoned},
+	})
+}
+
+// UpdateComment 编辑评论：PUT /api/comments/:id（作者在编辑窗口内，审核员随时）
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req dto.UpdateCommentReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "参数错误",
+			"detail":  err.Error(),
+		})
+		return
+	}
+	req.IP, req.UserAgent = c.ClientIP(), c.Request.UserAgent()
+
+	comment, err := h.svc.UpdateComment(c.Request.Context(), middleware.UID(c), middleware.Role(c), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrCommentNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "评论不存在"})
+		case errors.Is(err, service.ErrCommentForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "无权操作该评论"})
+		case errors.Is(err, service.ErrCommentEditExpired):
+			c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "已超过可编辑时间"})
+		case errors.Is(err, service.ErrCommentEditConflict):
+			c.JSON(http.StatusConflict, gin.H{"code": 409, "message": "评论状态已变化，请刷新后重试"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"code":    500,
+				"message": "编辑评论失败",
+				"detail":  err.Error(),
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": commentCreatedMessage(comment, "编辑评论成功"),
+		"data": gin.H{
+			"id":         comment.Id,
+			"content":    comment.Content,
+			"user_id":    comment.UserId,
+			"post_id":    comment.PostId,
+			"parent_id":  comment.ParentId,
+			"status":     comment.Status,
+			"mentions":   comment.Mentions,
+			"edited":     comment.EditedAt != nil,
+			"edited_at":  comment.EditedAt,
+			"created_at": comment.CreatedAt.Format(time.RFC3339),
+		},
+	})
+}
+
+// ListRevisions 查看评论的历史版本：GET /api/comments/:id/revisions（审核员）
+func (h *CommentHandler) ListRevisions(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	result, err := h.svc.ListRevisions(c.Request.Context(), id)
+	if err != nil {
+		h.renderListError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "查询成功",
+		"data":    result,
 	})
 }
 
@@ -120,28 +216,87 @@ func (h *CommentHandler) ListCommentsByPost(c *gin.Context) {
 		return
 	}
 
-	list, total, err := h.svc.ListCommentsByPost(c.Request.Context(), uint(postId))
+	page, pageSize := util.ParsePage(c)
+	replies, err := strconv.Atoi(c.DefaultQuery("replies", strconv.Itoa(h.previewReplies)))
+	if err != nil || replies < 0 || replies > maxPreviewReplies {
+		replies = h.previewReplies
+	}
+	query := dto.CommentListQuery{Page: page, PageSize: pageSize, Replies: replies}
+
+	result, err := h.svc.ListCommentsByPost(c.Request.Context(), middleware.UID(c), uint(postId), postPassword(c), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
-			"message": "查询评论失败",
-			"detail":  err.Error(),
-		})
+		h.renderListError(c, err)
 		return
 	}
-	page, pageSize := 1, int(total)
 	c.JSON(http.StatusOK, gin.H{
 		"code":    0,
 		"message": "查询评论成功",
-		"data": util.PageResult{
-			Page:     page,
-			PageSize: pageSize,
-			Total:    total,
-			List:     list,
-		},
+		"data":    result,
 	})
 }
 
+// ListReplies 按游标加载评论的直接回复：GET /api/comments/:id/replies?cursor=&limit=10
+func (h *CommentHandler) ListReplies(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	result, err := h.svc.ListReplies(c.Request.Context(), middleware.UID(c), id, postPassword(c), c.Query("cursor"), limit)
+	if err != nil {
+		h.renderListError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "查询回复成功",
+		"data":    result,
+	})
+}
+
+// GetComment 返回单条评论及其完整子树：GET /api/comments/:id
+func (h *CommentHandler) GetComment(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	result, err := h.svc.GetThread(c.Request.Context(), middleware.UID(c), id, postPassword(c))
+	if err != nil {
+		h.renderListError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "查询评论成功",
+		"data":    result,
+	})
+}
+
+// renderListError 评论查询类接口的错误映射。
+func (h *CommentHandler) renderListError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrPostMissing):
+		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "文章不存在"})
+	case errors.Is(err, service.ErrCommentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "评论不存在"})
+	case errors.Is(err, service.ErrPostPasswordRequired):
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "文章受密码保护，请提供正确密码"})
+	case errors.Is(err, service.ErrInvalidCursor):
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "无效的游标"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "查询评论失败",
+			"detail":  err.Error(),
+		})
+	}
+}
+
 // ReplyComment 回复评论
 func (h *CommentHandler) ReplyComment(c *gin.Context) {
 	var req dto.ReplyCommentReq
@@ -165,11 +320,17 @@ func (h *CommentHandler) ReplyComment(c *gin.Context) {
 	}
 
 	uid := middleware.UID(c)
-	comment, err := h.svc.ReplyToComment(c.Request.Context(), uid, uint(parentIdUint), req)
+	req.IP, req.UserAgent = c.ClientIP(), c.Request.UserAgent()
+	req.Password = postPassword(c)
+	comment, err := h.svc.ReplyToComment(c.Request.Context(), uid, middleware.Role(c), uint(parentIdUint), req)
 	if err != nil {
 		switch {
+		case errors.Is(err, service.ErrPostMissing):
+			c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "文章不存在"})
 		case errors.Is(err, service.ErrCommentNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "父评论不存在"})
+		case errors.Is(err, service.ErrPostPasswordRequired):
+			c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "文章受密码保护，请提供正确密码"})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"code":    500,
@@ -181,14 +342,24 @@ func (h *CommentHandler) ReplyComment(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, gin.H{
 		"code":    0,
-		"message": "创建回复成功",
+		"message": commentCreatedMessage(comment, "创建回复成功"),
 		"data": gin.H{
 			"id":         comment.Id,
 			"content":    comment.Content,
 			"user_id":    comment.UserId,
 			"post_id":    comment.PostId,
 			"parent_id":  comment.ParentId,
+			"status":     comment.Status,
+			"mentions":   comment.Mentions,
 			"created_at": comment.CreatedAt.Format(time.RFC3339),
 		},
 	})
 }
+
+// commentCreatedMessage 评论进入待审核队列时提示等待审核。
+func commentCreatedMessage(comment *model.Comment, ok string) string {
+	if comment.Status == model.CommentStatusPending || comment.Status == model.CommentStatusSpam {
+		return "评论已提交，等待审核"
+	}
+	return ok
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"go-blog/internal/dto"
+	"go-blog/internal/importer"
+	"go-blog/internal/middleware"
+	"go-blog/internal/service"
+	"go-blog/internal/util"
+)
+
+// ImportHandler 处理后台批量导入相关 HTTP 请求。
+type ImportHandler struct {
+	svc     *service.ImportService
+	maxSize int64
+}
+
+// NewImportHandler 构造导入处理器，上传包大小上限由 IMPORT_MAX_SIZE（MB）配置。
+func NewImportHandler(svc *service.ImportService) *ImportHandler {
+	return &ImportHandler{svc: svc, maxSize: int64(util.EnvInt("IMPORT_MAX_SIZE", 50)) << 20}
+}
+
+// ImportMarkdown 导入 Markdown 文章 ZIP 包：POST /api/admin/import/markdown
+// 表单字段：file（ZIP）、dry_run、user_id（默认当前管理员）、default_category。
+func (h *ImportHandler) ImportMarkdown(c *gin.Context) {
+	file, opts, ok := h.parseForm(c, ".zip")
+	if !ok {
+		return
+	}
+	src, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "读取文件失败", "detail": err.Error()})
+		return
+	}
+	defer src.Close()
+	fsys, err := importer.ZipFS(src)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "无效的 ZIP 文件", "detail": err.Error()})
+		return
+	}
+
+	report, err := h.svc.ImportMarkdown(c.Request.Context(), fsys, opts)
+	h.render(c, report, err)
+}
+
+// ImportWordPress 导入 WordPress 导出文件（WXR）：POST /api/admin/import/wordpress
+// 表单字段同 Markdown 导入，file 为 .xml；user_id 作为未知作者的文章所有者。
+func (h *ImportHandler) ImportWordPress(c *gin.Context) {
+	file, opts, ok := h.parseForm(c, ".xml")
+	if !ok {
+		return
+	}
+	src, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "读取文件失败", "detail": err.Error()})
+		return
+	}
+	defer src.Close()
+
+	report, err := h.svc.ImportWordPress(c.Request.Context(), src, opts)
+	h.render(c, report, err)
+}
+
+// ImportDisqus 导入 Disqus 评论导出文件：POST /api/admin/import/disqus
+// 表单字段：file（.xml）、dry_run。
+func (h *ImportHandler) ImportDisqus(c *gin.Context) {
+	file, opts, ok := h.parseForm(c, ".xml")
+	if !ok {
+		return
+	}
+	src, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "读取文件失败", "detail": err.Error()})
+		return
+	}
+	defer src.Close()
+
+	report, err := h.svc.ImportDisqus(c.Request.Context(), src, opts)
+	h.render(c, report, err)
+}
+
+// parseForm 读取上传文件（校验扩展名）与通用导入参数，失败时直接写回 400。
+func (h *ImportHandler) parseForm(c *gin.Context, ext string) (*multipart.FileHeader, service.ImportOptions, bool) {
+	opts := service.ImportOptions{
+		UserID:          middleware.UID(c),
+		DefaultCategory: strings.TrimSpace(c.PostForm("default_category")),
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxSize)
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "请上传导入文件",
+			"detail":  err.Error(),
+		})
+		return nil, opts, false
+	}
+	if !strings.HasSuffix(strings.ToLower(file.Filename), ext) {
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "仅支持 " + ext + " 文件"})
+		return nil, opts, false
+	}
+
+	opts.DryRun, _ = strconv.ParseBool(c.PostForm("dry_run"))
+	if v := c.PostForm("user_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil || id == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误"})
+			return nil, opts, false
+		}
+		opts.UserID = uint(id)
+	}
+	return file, opts, true
+}
+
+// render 输出导入报告或错误。
+func (h *ImportHandler) render(c *gin.Context, report *dto.ImportReport, err error) {
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, gin.H{
+			"code":    0,
+			"message": "导入完成",
+			"data":    report,
+		})
+	case errors.Is(err, service.ErrUserNotFound):
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "用户不存在"})
+	case errors.Is(err, service.ErrInvalidImportFile):
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "无法解析导入文件", "detail": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "导入失败",
+			"detail":  err.Error(),
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"go-blog/internal/dto"
+	"go-blog/internal/model"
+	"go-blog/internal/service"
+	"go-blog/internal/util"
+)
+
+// ModerationHandler 评论审核队列接口（审核员角色）。
+type ModerationHandler struct {
+	svc *service.CommentService
+}
+
+// NewModerationHandler 构造审核处理器。
+func NewModerationHandler(svc *service.CommentService) *ModerationHandler {
+	return &ModerationHandler{svc: svc}
+}
+
+// ListQueue 审核队列：GET /api/moderation/comments?status=pending&post_id=&page=&page_size=
+func (h *ModerationHandler) ListQueue(c *gin.Context) {
+	page, pageSize := util.ParsePage(c)
+	var postID *uint
+	if pidStr := c.Query("post_id"); pidStr != "" {
+		if pid64, err := strconv.ParseUint(pidStr, 10, 64); err == nil && pid64 > 0 {
+			pid := uint(pid64)
+			postID = &pid
+		}
+	}
+
+	list, total, err := h.svc.ModerationQueue(c.Request.Context(), c.Query("status"), postID, page, pageSize)
+	if err != nil {
+		h.render(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code": 0,
+		"data": util.PageResult{
+			Page:     page,
+			PageSize: pageSize,
+			Total:    total,
+			List:     list,
+		},
+	})
+}
+
+// Approve 批量通过：POST /api/moderation/comments/approve
+func (h *ModerationHandler) Approve(c *gin.Context) {
+	h.moderate(c, model.CommentStatusApproved)
+}
+
+// Reject 批量拒绝：POST /api/moderation/comments/reject
+func (h *ModerationHandler) Reject(c *gin.Context) {
+	h.moderate(c, model.CommentStatusRejected)
+}
+
+// MarkSpam 批量标记为垃圾评论：POST /api/moderation/comments/spam
+func (h *ModerationHandler) MarkSpam(c *gin.Context) {
+	h.moderate(c, model.CommentStatusSpam)
+}
+
+func (h *ModerationHandler) moderate(c *gin.Context, status string) {
+	var req dto.ModerateCommentsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "参数错误",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	n, err := h.svc.ModerateComments(c.Request.Context(), req.Ids, status)
+	if err != nil {
+		h.render(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "审核成功",
+		"data":    gin.H{"updated": n},
+	})
+}
+
+func (h *ModerationHandler) render(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidModeration):
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "无效的审核状态"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "审核操作失败",
+			"detail":  err.Error(),
+		})
+	}
+}
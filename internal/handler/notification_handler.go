@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"go-blog/internal/dto"
+	"go-blog/internal/middleware"
+	"go-blog/internal/service"
+	"go-blog/internal/util"
+)
+
+// NotificationHandler 处理站内通知相关 HTTP 请求。
+type NotificationHandler struct{ svc *service.NotificationService }
+
+func NewNotificationHandler(svc *service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{svc: svc}
+}
+
+// ListNotifications 分页查询我的通知：GET /api/me/notifications?unread=true
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	page, pageSize := util.ParsePage(c)
+	result, err := h.svc.ListNotifications(c.Request.Context(), middleware.UID(c), c.Query("unread") == "true", page, pageSize)
+	if err != nil {
+		h.renderError(c, err, "查询通知失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "ok",
+		"data":    result,
+	})
+}
+
+// UnreadCount 未读通知数：GET /api/me/notifications/unread-count
+func (h *NotificationHandler) UnreadCount(c *gin.Context) {
+	result, err := h.svc.UnreadCount(c.Request.Context(), middleware.UID(c))
+	if err != nil {
+		h.renderError(c, err, "查询未读数失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "ok",
+		"data":    result,
+	})
+}
+
+// MarkRead 标记一条通知为已读：POST /api/me/notifications/:id/read
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.svc.MarkRead(c.Request.Context(), middleware.UID(c), id); err != nil {
+		h.renderError(c, err, "标记已读失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "已读",
+	})
+}
+
+// MarkAllRead 全部标记为已读：POST /api/me/notifications/read-all
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	n, err := h.svc.MarkAllRead(c.Request.Context(), middleware.UID(c))
+	if err != nil {
+		h.renderError(c, err, "标记已读失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "已全部标记为已读",
+		"data":    gin.H{"updated": n},
+	})
+}
+
+// GetPreferences 查询通知偏好：GET /api/me/notification-preferences
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	prefs, err := h.svc.Preferences(c.Request.Context(), middleware.UID(c))
+	if err != nil {
+		h.renderError(c, err, "查询通知偏好失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "ok",
+		"data":    prefs,
+	})
+}
+
+// UpdatePreferences 更新通知偏好：PUT /api/me/notification-preferences
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	var req dto.UpdateNotificationPreferencesReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "参数错误",
+			"detail":  err.Error(),
+		})
+		return
+	}
+	prefs, err := h.svc.UpdatePreferences(c.Request.Context(), middleware.UID(c), req.Preferences)
+	if err != nil {
+		h.renderError(c, err, "更新通知偏好失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "更新成功",
+		"data":    prefs,
+	})
+}
+
+func (h *NotificationHandler) renderError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrNotificationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "通知不存在"})
+	case errors.Is(err, service.ErrInvalidNotificationType):
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "无效的通知类型"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": fallback,
+			"detail":  err.Error(),
+		})
+	}
+}
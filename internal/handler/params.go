@@ -0,0 +1,18 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parseIDParam 解析路径中的正整数ID，失败时直接写回 400。
+func parseIDParam(c *gin.Context, name string) (uint, bool) {
+	id64, err := strconv.ParseUint(c.Param(name), 10, 64)
+	if err != nil || id64 == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误"})
+		return 0, false
+	}
+	return uint(id64), true
+}
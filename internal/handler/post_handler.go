@@ -7,6 +7,7 @@ import (
 	"go-blog/internal/middleware"
 	"go-blog/internal/repository"
 	"go-blog/internal/service"
+	"go-blog/internal/util"
 	"net/http"
 	"strconv"
 	"strings"
@@ -36,8 +37,19 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 
 	uid := middleware.UID(c)
 
-	post, err := h.svc.CreatePost(c.Request.Context(), uid, req)
+	post, err := h.svc.CreatePost(c.Request.Context(), uid, middleware.Role(c), req)
 	if err != nil {
+		if errors.Is(err, service.ErrPostPasswordMissing) {
+			c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "密码保护文章需设置访问密码"})
+			return
+		}
+		if errors.Is(err, service.ErrSpamDetected) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"code": 422, "message": "内容疑似垃圾信息"})
+			return
+		}
+		if renderWorkflowError(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
 			"message": "创建文章失败",
@@ -61,7 +73,7 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 
 // GetAllPosts 获取所有文章列表（预加载作者信息）。
 func (h *PostHandler) GetAllPosts(c *gin.Context) {
-	posts, err := h.svc.GetAllPosts(c.Request.Context())
+	posts, err := h.svc.GetAllPosts(c.Request.Context(), middleware.UID(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
@@ -89,11 +101,13 @@ func (h *PostHandler) GetPostsById(c *gin.Context) {
 	}
 	id := uint(id64)
 
-	post, err := h.svc.GetPostByID(c.Request.Context(), id)
+	post, err := h.svc.GetPostByID(c.Request.Context(), middleware.UID(c), id, postPassword(c))
 	if err != nil {
 		switch {
 		case errors.Is(err, service.ErrPostNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "文章不存在"})
+		case errors.Is(err, service.ErrPostPasswordRequired):
+			c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "文章受密码保护，请提供正确密码"})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"code":    500,
@@ -104,6 +118,9 @@ func (h *PostHandler) GetPostsById(c *gin.Context) {
 		return
 	}
 
+	h.svc.RecordView(post, visitorKey(c))
+
+	c.Header("ETag", postETag(post.Version))
 	c.JSON(http.StatusOK, gin.H{
 		"code":    0,
 		"message": "查询成功",
@@ -111,7 +128,37 @@ func (h *PostHandler) GetPostsById(c *gin.Context) {
 	})
 }
 
-// UpdatePost 更新文章内容：仅作者本人可更新，空字段不覆盖。
+// postPassword 读取密码保护文章的访问密码（X-Post-Password 请求头）。
+func postPassword(c *gin.Context) string {
+	return c.GetHeader("X-Post-Password")
+}
+
+// visitorKey 生成浏览去重用的访客标识；文章接口均需登录，因此按用户去重。
+func visitorKey(c *gin.Context) string {
+	return "u:" + strconv.FormatUint(uint64(middleware.UID(c)), 10)
+}
+
+// postETag 以文章版本号生成 ETag。
+func postETag(version uint64) string {
+	return `"` + strconv.FormatUint(version, 10) + `"`
+}
+
+// parseIfMatch 解析 If-Match 中的文章版本号；"*" 或缺省时返回 ok=false。
+func parseIfMatch(c *gin.Context) (version uint64, ok bool, err error) {
+	v := strings.TrimSpace(c.GetHeader("If-Match"))
+	if v == "" || v == "*" {
+		return 0, false, nil
+	}
+	v = strings.Trim(strings.TrimPrefix(v, "W/"), `"`)
+	version, err = strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	return version, true, nil
+}
+
+// UpdatePost 更新文章内容：所有者与合著者可更新，空字段不覆盖。
+// 通过 If-Match（版本不符返回 412）或请求体 version（返回 409）实现乐观并发控制。
 func (h *PostHandler) UpdatePost(c *gin.Context) {
 	idStr := c.Param("id")
 	id64, err := strconv.ParseUint(idStr, 10, 64)
@@ -131,15 +178,43 @@ func (h *PostHandler) UpdatePost(c *gin.Context) {
 		return
 	}
 
+	ifMatch, hasIfMatch, err := parseIfMatch(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "If-Match 格式错误"})
+		return
+	}
+	if hasIfMatch {
+		req.Version = &ifMatch
+	}
+
 	uid := middleware.UID(c)
 
-	post, err := h.svc.UpdatePost(c.Request.Context(), uid, id, req)
+	post, err := h.svc.UpdatePost(c.Request.Context(), uid, middleware.Role(c), id, req)
 	if err != nil {
+		if renderWorkflowError(c, err) {
+			return
+		}
+		var conflict *service.PostConflictError
 		switch {
 		case errors.Is(err, service.ErrPostNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "文章不存在"})
 		case errors.Is(err, service.ErrForbidden):
 			c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "无权操作该文章"})
+		case errors.Is(err, service.ErrPostPasswordMissing):
+			c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "密码保护文章需设置访问密码"})
+		case errors.Is(err, service.ErrSpamDetected):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"code": 422, "message": "内容疑似垃圾信息"})
+		case errors.As(err, &conflict):
+			status := http.StatusConflict
+			if hasIfMatch {
+				status = http.StatusPreconditionFailed
+			}
+			c.Header("ETag", postETag(conflict.Current))
+			c.JSON(status, gin.H{
+				"code":    status,
+				"message": "文章已被他人修改，请刷新后重试",
+				"data":    gin.H{"version": conflict.Current},
+			})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"code":    500,
@@ -150,6 +225,7 @@ func (h *PostHandler) UpdatePost(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", postETag(post.Version))
 	c.JSON(http.StatusOK, gin.H{
 		"code":    0,
 		"message": "更新成功",
@@ -157,7 +233,7 @@ func (h *PostHandler) UpdatePost(c *gin.Context) {
 	})
 }
 
-// DeletePost 删除文章：仅作者本人可删除。
+// DeletePost 删除文章：仅所有者可删除。
 func (h *PostHandler) DeletePost(c *gin.Context) {
 	idStr := c.Param("id")
 	id64, err := strconv.ParseUint(idStr, 10, 64)
@@ -197,6 +273,45 @@ func (h *PostHandler) ListPosts(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
 
+	categoryID, tagIDs := parseCategoryTags(c)
+
+	var status *string
+	if st := c.Query("status"); st != "" {
+		status = &st
+	}
+
+	keyword := c.Query("keyword")
+	order := c.DefaultQuery("order", "latest")
+
+	filter := repository.PostFilter{
+		CategoryID: categoryID,
+		TagIDs:     tagIDs,
+		Status:     status,
+		Keyword:    keyword,
+		Order:      order,
+		Page:       page,
+		PageSize:   pageSize,
+	}
+
+	posts, total, err := h.svc.ListPosts(c.Request.Context(), middleware.UID(c), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "查询文章失败", "detail": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code": 0,
+		"data": gin.H{
+			"list":     posts,
+			"total":    total,
+			"page":     page,
+			"pageSize": pageSize,
+		},
+	})
+}
+
+// parseCategoryTags 解析 category_id 与 tag_ids=1,2,3 查询参数。
+func parseCategoryTags(c *gin.Context) (*uint, []uint) {
 	var categoryID *uint
 	if cidStr := c.Query("category_id"); cidStr != "" {
 		if cid64, err := strconv.ParseUint(cidStr, 10, 64); err == nil {
@@ -205,7 +320,6 @@ func (h *PostHandler) ListPosts(c *gin.Context) {
 		}
 	}
 
-	// tag_ids=1,2,3
 	var tagIDs []uint
 	if tidStr := c.Query("tag_ids"); tidStr != "" {
 		for _, s := range strings.Split(tidStr, ",") {
@@ -217,38 +331,293 @@ func (h *PostHandler) ListPosts(c *gin.Context) {
 			}
 		}
 	}
+	return categoryID, tagIDs
+}
 
-	var status *string
-	if st := c.Query("status"); st != "" {
-		status = &st
+// Archive 按年月统计已发布文章数：GET /api/archive?category_id=&tag_ids=
+func (h *PostHandler) Archive(c *gin.Context) {
+	categoryID, tagIDs := parseCategoryTags(c)
+	years, err := h.svc.Archive(c.Request.Context(), middleware.UID(c), categoryID, tagIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "查询归档失败", "detail": err.Error()})
+		return
 	}
+	c.JSON(http.StatusOK, gin.H{
+		"code": 0,
+		"data": years,
+	})
+}
 
-	keyword := c.Query("keyword")
-	order := c.DefaultQuery("order", "latest")
+// ArchivePosts 某年（或某年某月）的文章列表：GET /api/archive/:year 与 /api/archive/:year/:month
+func (h *PostHandler) ArchivePosts(c *gin.Context) {
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误"})
+		return
+	}
+	month := 0
+	if m := c.Param("month"); m != "" {
+		if month, err = strconv.Atoi(m); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误"})
+			return
+		}
+	}
+	page, pageSize := util.ParsePage(c)
+	categoryID, tagIDs := parseCategoryTags(c)
 
-	filter := repository.PostFilter{
+	posts, total, err := h.svc.ArchivePosts(c.Request.Context(), middleware.UID(c), year, month, repository.PostFilter{
 		CategoryID: categoryID,
 		TagIDs:     tagIDs,
-		Status:     status,
-		Keyword:    keyword,
-		Order:      order,
 		Page:       page,
 		PageSize:   pageSize,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidArchiveDate) {
+			c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "年月不合法"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "查询文章失败", "detail": err.Error()})
+		return
 	}
+	c.JSON(http.StatusOK, gin.H{
+		"code": 0,
+		"data": util.PageResult{
+			Page:     page,
+			PageSize: pageSize,
+			Total:    total,
+			List:     posts,
+		},
+	})
+}
 
-	posts, total, err := h.svc.ListPosts(c.Request.Context(), filter)
+// ListAuthors 查询文章作者（所有者与合著者）：GET /api/posts/:id/authors
+func (h *PostHandler) ListAuthors(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	authors, err := h.svc.ListAuthors(c.Request.Context(), middleware.UID(c), id, postPassword(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "查询文章失败", "detail": err.Error()})
+		h.renderAuthorError(c, err, "查询作者失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "查询成功",
+		"data":    authors,
+	})
+}
+
+// AddCoAuthor 邀请合著者（仅所有者）：POST /api/posts/:id/authors
+func (h *PostHandler) AddCoAuthor(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req dto.AddCoAuthorReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "参数错误",
+			"detail":  err.Error(),
+		})
+		return
+	}
+	author, err := h.svc.AddCoAuthor(c.Request.Context(), middleware.UID(c), id, req.UserId)
+	if err != nil {
+		h.renderAuthorError(c, err, "邀请合著者失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "邀请成功",
+		"data":    author,
+	})
+}
+
+// RemoveCoAuthor 移除合著者（仅所有者）：DELETE /api/posts/:id/authors/:user_id
+func (h *PostHandler) RemoveCoAuthor(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	targetID, ok := parseIDParam(c, "user_id")
+	if !ok {
+		return
+	}
+	if err := h.svc.RemoveCoAuthor(c.Request.Context(), middleware.UID(c), id, targetID); err != nil {
+		h.renderAuthorError(c, err, "移除合著者失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "移除成功",
+	})
+}
+
+func (h *PostHandler) renderAuthorError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrPostNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "文章不存在"})
+	case errors.Is(err, service.ErrPostPasswordRequired):
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "文章受密码保护，请提供正确密码"})
+	case errors.Is(err, service.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "用户不存在"})
+	case errors.Is(err, service.ErrAuthorNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "该用户不是合著者"})
+	case errors.Is(err, service.ErrAuthorExists):
+		c.JSON(http.StatusConflict, gin.H{"code": 409, "message": "该用户已是作者"})
+	case errors.Is(err, service.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "仅文章所有者可管理合著者"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": message,
+			"detail":  err.Error(),
+		})
+	}
+}
+
+// TransitionPost 变更文章状态（提交审核、批准、退回修改、发布）：POST /api/posts/:id/transitions
+func (h *PostHandler) TransitionPost(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req dto.TransitionPostReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "参数错误",
+			"detail":  err.Error(),
+		})
 		return
 	}
 
+	post, err := h.svc.TransitionPost(c.Request.Context(), middleware.UID(c), middleware.Role(c), id, req.Status, req.Comment)
+	if err != nil {
+		if renderWorkflowError(c, err) {
+			return
+		}
+		var conflict *service.PostConflictError
+		switch {
+		case errors.Is(err, service.ErrPostNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "文章不存在"})
+		case errors.Is(err, service.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "无权操作该文章"})
+		case errors.As(err, &conflict):
+			c.JSON(http.StatusConflict, gin.H{
+				"code":    409,
+				"message": "文章已被他人修改，请刷新后重试",
+				"data":    gin.H{"version": conflict.Current},
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"code":    500,
+				"message": "变更状态失败",
+				"detail":  err.Error(),
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "状态已更新",
+		"data":    gin.H{"id": post.ID, "status": post.Status, "version": post.Version},
+	})
+}
+
+// ListTransitions 查询文章状态流转记录：GET /api/posts/:id/transitions
+func (h *PostHandler) ListTransitions(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	list, err := h.svc.ListTransitions(c.Request.Context(), middleware.UID(c), middleware.Role(c), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrPostNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "文章不存在"})
+		case errors.Is(err, service.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "无权查看该文章"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"code":    500,
+				"message": "查询失败",
+				"detail":  err.Error(),
+			})
+		}
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"code": 0,
-		"data": gin.H{
-			"list":     posts,
-			"total":    total,
-			"page":     page,
-			"pageSize": pageSize,
+		"data": list,
+	})
+}
+
+// ReviewQueue 待审核文章列表（审核者）：GET /api/reviews
+func (h *PostHandler) ReviewQueue(c *gin.Context) {
+	page, pageSize := util.ParsePage(c)
+	posts, total, err := h.svc.ListReviewQueue(c.Request.Context(), page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "查询失败",
+			"detail":  err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code": 0,
+		"data": util.PageResult{
+			Page:     page,
+			PageSize: pageSize,
+			Total:    total,
+			List:     posts,
 		},
 	})
 }
+
+// renderWorkflowError 输出审核流程相关错误，返回是否已处理。
+func renderWorkflowError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, service.ErrInvalidStatus):
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "无效的文章状态"})
+	case errors.Is(err, service.ErrTransitionNotAllowed):
+		c.JSON(http.StatusConflict, gin.H{"code": 409, "message": "不允许的状态变更"})
+	case errors.Is(err, service.ErrReviewCommentRequired):
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "退回修改需填写审核意见"})
+	default:
+		return false
+	}
+	return true
+}
+
+// RelatedPosts 相关文章推荐：GET /api/posts/:id/related?limit=5
+func (h *PostHandler) RelatedPosts(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "5"))
+	list, err := h.svc.RelatedPosts(c.Request.Context(), middleware.UID(c), id, postPassword(c), limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrPostNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "文章不存在"})
+		case errors.Is(err, service.ErrPostPasswordRequired):
+			c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "文章受密码保护，请提供正确密码"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"code":    500,
+				"message": "查询相关文章失败",
+				"detail":  err.Error(),
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code": 0,
+		"data": list,
+	})
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"go-blog/internal/dto"
+	"go-blog/internal/middleware"
+	"go-blog/internal/service"
+)
+
+// PreviewHandler 处理草稿预览链接相关 HTTP 请求。
+type PreviewHandler struct{ svc *service.PreviewService }
+
+func NewPreviewHandler(svc *service.PreviewService) *PreviewHandler {
+	return &PreviewHandler{svc: svc}
+}
+
+// CreatePreview 生成预览链接：POST /api/posts/:id/previews
+func (h *PreviewHandler) CreatePreview(c *gin.Context) {
+	postID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req dto.CreatePreviewReq
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"code":    400,
+				"message": "参数错误",
+				"detail":  err.Error(),
+			})
+			return
+		}
+	}
+	resp, err := h.svc.CreatePreview(c.Request.Context(), middleware.UID(c), postID, req)
+	if err != nil {
+		h.renderError(c, err, "创建预览链接失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "创建预览链接成功",
+		"data":    resp,
+	})
+}
+
+// ListPreviews 列出有效的预览链接：GET /api/posts/:id/previews
+func (h *PreviewHandler) ListPreviews(c *gin.Context) {
+	postID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	list, err := h.svc.ListPreviews(c.Request.Context(), middleware.UID(c), postID)
+	if err != nil {
+		h.renderError(c, err, "查询预览链接失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code": 0,
+		"data": list,
+	})
+}
+
+// RevokePreview 撤销预览链接：DELETE /api/posts/:id/previews/:preview_id
+func (h *PreviewHandler) RevokePreview(c *gin.Context) {
+	postID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	previewID, ok := parseIDParam(c, "preview_id")
+	if !ok {
+		return
+	}
+	if err := h.svc.RevokePreview(c.Request.Context(), middleware.UID(c), postID, previewID); err != nil {
+		h.renderError(c, err, "撤销预览链接失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "已撤销",
+	})
+}
+
+// OpenPreview 凭令牌只读查看草稿（无需登录）：GET /api/shared/previews/:token
+func (h *PreviewHandler) OpenPreview(c *gin.Context) {
+	resp, err := h.svc.OpenPreview(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		h.renderError(c, err, "查询预览失败")
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Robots-Tag", "noindex")
+	c.JSON(http.StatusOK, gin.H{
+		"code": 0,
+		"data": resp,
+	})
+}
+
+func (h *PreviewHandler) renderError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrPostNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "文章不存在"})
+	case errors.Is(err, service.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "无权操作该文章"})
+	case errors.Is(err, service.ErrPreviewPublished):
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "文章已发布，无需预览链接"})
+	case errors.Is(err, service.ErrPreviewNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "预览链接不存在"})
+	case errors.Is(err, service.ErrPreviewInvalid):
+		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "预览链接无效或已过期"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": message,
+			"detail":  err.Error(),
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"go-blog/internal/dto"
+	"go-blog/internal/middleware"
+	"go-blog/internal/service"
+)
+
+// ReactionHandler 处理点赞与表情表态相关 HTTP 请求。
+type ReactionHandler struct{ svc *service.ReactionService }
+
+func NewReactionHandler(svc *service.ReactionService) *ReactionHandler {
+	return &ReactionHandler{svc: svc}
+}
+
+// ListEmojis 返回允许的表态集合：GET /api/reactions/emojis
+func (h *ReactionHandler) ListEmojis(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "ok",
+		"data":    h.svc.Emojis(),
+	})
+}
+
+// TogglePostReaction 切换文章表态：POST /api/posts/:id/reactions
+func (h *ReactionHandler) TogglePostReaction(c *gin.Context) {
+	id, req, ok := h.bind(c)
+	if !ok {
+		return
+	}
+	resp, err := h.svc.TogglePostReaction(c.Request.Context(), middleware.UID(c), id, postPassword(c), req.Emoji)
+	h.render(c, resp, err)
+}
+
+// ToggleCommentReaction 切换评论表态：POST /api/comments/:id/reactions
+func (h *ReactionHandler) ToggleCommentReaction(c *gin.Context) {
+	id, req, ok := h.bind(c)
+	if !ok {
+		return
+	}
+	resp, err := h.svc.ToggleCommentReaction(c.Request.Context(), middleware.UID(c), id, postPassword(c), req.Emoji)
+	h.render(c, resp, err)
+}
+
+func (h *ReactionHandler) bind(c *gin.Context) (uint, dto.ToggleReactionReq, bool) {
+	var req dto.ToggleReactionReq
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id64 == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误"})
+		return 0, req, false
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "参数错误",
+			"detail":  err.Error(),
+		})
+		return 0, req, false
+	}
+	return uint(id64), req, true
+}
+
+func (h *ReactionHandler) render(c *gin.Context, resp *dto.ReactionResp, err error) {
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidReaction):
+			c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "不支持的表态", "data": h.svc.Emojis()})
+		case errors.Is(err, service.ErrPostNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "文章不存在"})
+		case errors.Is(err, service.ErrCommentNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "评论不存在"})
+		case errors.Is(err, service.ErrPostPasswordRequired):
+			c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "文章受密码保护，请提供正确密码"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"code":    500,
+				"message": "表态失败",
+				"detail":  err.Error(),
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "ok",
+		"data":    resp,
+	})
+}
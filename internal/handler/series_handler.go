@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"go-blog/internal/dto"
+	"go-blog/internal/middleware"
+	"go-blog/internal/service"
+	"go-blog/internal/util"
+)
+
+// SeriesHandler 处理文章系列相关 HTTP 请求。
+type SeriesHandler struct{ svc *service.SeriesService }
+
+func NewSeriesHandler(svc *service.SeriesService) *SeriesHandler { return &SeriesHandler{svc: svc} }
+
+// CreateSeries 创建系列：POST /api/series
+func (h *SeriesHandler) CreateSeries(c *gin.Context) {
+	var req dto.CreateSeriesReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "参数错误",
+			"detail":  err.Error(),
+		})
+		return
+	}
+	uid := middleware.UID(c)
+	series, err := h.svc.CreateSeries(c.Request.Context(), uid, req)
+	if err != nil {
+		h.renderError(c, err, "创建系列失败")
+		return
+	}
+	resp, err := h.svc.GetSeries(c.Request.Context(), uid, series.Id)
+	if err != nil {
+		h.renderError(c, err, "创建系列失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "创建系列成功",
+		"data":    resp,
+	})
+}
+
+// ListSeries 系列列表：GET /api/series?user_id=&page=&page_size=
+func (h *SeriesHandler) ListSeries(c *gin.Context) {
+	page, pageSize := util.ParsePage(c)
+	var userID *uint
+	if uidStr := c.Query("user_id"); uidStr != "" {
+		if uid64, err := strconv.ParseUint(uidStr, 10, 64); err == nil && uid64 > 0 {
+			uid := uint(uid64)
+			userID = &uid
+		}
+	}
+	list, total, err := h.svc.ListSeries(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		h.renderError(c, err, "查询系列失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code": 0,
+		"data": util.PageResult{
+			Page:     page,
+			PageSize: pageSize,
+			Total:    total,
+			List:     list,
+		},
+	})
+}
+
+// GetSeries 系列详情（含有序篇目）：GET /api/series/:id
+func (h *SeriesHandler) GetSeries(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	resp, err := h.svc.GetSeries(c.Request.Context(), middleware.UID(c), id)
+	if err != nil {
+		h.renderError(c, err, "查询系列失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code": 0,
+		"data": resp,
+	})
+}
+
+// UpdateSeries 更新系列：PUT /api/series/:id
+func (h *SeriesHandler) UpdateSeries(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req dto.UpdateSeriesReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "参数错误",
+			"detail":  err.Error(),
+		})
+		return
+	}
+	if _, err := h.svc.UpdateSeries(c.Request.Context(), middleware.UID(c), id, req); err != nil {
+		h.renderError(c, err, "更新系列失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "更新成功",
+	})
+}
+
+// SetSeriesPosts 设置或重排系列文章：PUT /api/series/:id/posts
+func (h *SeriesHandler) SetSeriesPosts(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req dto.SetSeriesPostsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "参数错误",
+			"detail":  err.Error(),
+		})
+		return
+	}
+	uid := middleware.UID(c)
+	if err := h.svc.SetSeriesPosts(c.Request.Context(), uid, id, req.PostIds); err != nil {
+		h.renderError(c, err, "更新系列文章失败")
+		return
+	}
+	resp, err := h.svc.GetSeries(c.Request.Context(), uid, id)
+	if err != nil {
+		h.renderError(c, err, "查询系列失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "更新成功",
+		"data":    resp,
+	})
+}
+
+// DeleteSeries 删除系列（文章保留）：DELETE /api/series/:id
+func (h *SeriesHandler) DeleteSeries(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.svc.DeleteSeries(c.Request.Context(), middleware.UID(c), id); err != nil {
+		h.renderError(c, err, "删除系列失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "删除成功",
+	})
+}
+
+func (h *SeriesHandler) renderError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrSeriesNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "系列不存在"})
+	case errors.Is(err, service.ErrSeriesForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "无权操作该系列或文章"})
+	case errors.Is(err, service.ErrSeriesInvalidPost):
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "文章不存在或重复"})
+	case errors.Is(err, service.ErrSeriesPostTaken):
+		c.JSON(http.StatusConflict, gin.H{"code": 409, "message": "文章已属于其他系列"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": message,
+			"detail":  err.Error(),
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"go-blog/internal/middleware"
+	"go-blog/internal/realtime"
+	"go-blog/internal/service"
+)
+
+// sseRetry 建议客户端断线后的重连间隔（毫秒）。
+const sseRetry = 3000
+
+// StreamHandler 处理 Server-Sent Events 实时推送连接。
+type StreamHandler struct {
+	streams  *service.StreamService
+	comments *service.CommentService
+}
+
+func NewStreamHandler(streams *service.StreamService, comments *service.CommentService) *StreamHandler {
+	return &StreamHandler{streams: streams, comments: comments}
+}
+
+// PostComments 文章评论实时流：GET /api/posts/:id/stream
+func (h *StreamHandler) PostComments(c *gin.Context) {
+	postID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.comments.AuthorizeStream(c.Request.Context(), middleware.UID(c), postID, postPassword(c)); err != nil {
+		switch {
+		case errors.Is(err, service.ErrPostMissing):
+			c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "文章不存在"})
+		case errors.Is(err, service.ErrPostPasswordRequired):
+			c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "文章受密码保护，请提供正确密码"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "订阅评论失败", "detail": err.Error()})
+		}
+		return
+	}
+	h.serve(c, service.PostTopic(postID))
+}
+
+// Notifications 当前用户的通知实时流：GET /api/me/notifications/stream
+func (h *StreamHandler) Notifications(c *gin.Context) {
+	h.serve(c, service.UserTopic(middleware.UID(c)))
+}
+
+// serve 订阅主题并持续写出事件，空闲时按心跳间隔发送注释行，直到客户端断开。
+// 续传位置取自 Last-Event-ID 请求头（浏览器重连时自动携带），也可用 last_event_id 查询参数指定。
+func (h *StreamHandler) serve(c *gin.Context, topic string) {
+	lastID := c.GetHeader("Last-Event-ID")
+	if lastID == "" {
+		lastID = c.Query("last_event_id")
+	}
+	ctx := c.Request.Context()
+	events, err := h.streams.Subscribe(ctx, topic, lastID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "订阅失败", "detail": err.Error()})
+		return
+	}
+
+	w := c.Writer
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // 关闭 Nginx 缓冲
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", sseRetry)
+	w.Flush()
+
+	heartbeat := time.NewTicker(h.streams.Heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-events:
+			if !ok {
+				// 代理断开了过慢的订阅者，客户端重连后按 Last-Event-ID 续传
+				return
+			}
+			if err := writeEvent(w, ev); err != nil {
+				return
+			}
+			w.Flush()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			w.Flush()
+		}
+	}
+}
+
+// writeEvent 按 SSE 格式写出一条事件（数据为单行 JSON）。
+func writeEvent(w gin.ResponseWriter, ev realtime.Event) error {
+	if ev.ID != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", ev.ID); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.Type, ev.Data)
+	return err
+}
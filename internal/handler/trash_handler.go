@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"go-blog/internal/middleware"
+	"go-blog/internal/service"
+	"go-blog/internal/util"
+)
+
+// TrashHandler 处理回收站相关 HTTP 请求。
+type TrashHandler struct{ svc *service.TrashService }
+
+func NewTrashHandler(svc *service.TrashService) *TrashHandler { return &TrashHandler{svc: svc} }
+
+// MyTrashedPosts 当前用户回收站中的文章：GET /api/me/trash/posts
+func (h *TrashHandler) MyTrashedPosts(c *gin.Context) {
+	uid := middleware.UID(c)
+	h.listPosts(c, &uid)
+}
+
+// MyTrashedComments 当前用户回收站中的评论：GET /api/me/trash/comments
+func (h *TrashHandler) MyTrashedComments(c *gin.Context) {
+	uid := middleware.UID(c)
+	h.listComments(c, &uid)
+}
+
+// AdminTrashedPosts 全站回收站文章：GET /api/admin/trash/posts
+func (h *TrashHandler) AdminTrashedPosts(c *gin.Context) { h.listPosts(c, nil) }
+
+// AdminTrashedComments 全站回收站评论：GET /api/admin/trash/comments
+func (h *TrashHandler) AdminTrashedComments(c *gin.Context) { h.listComments(c, nil) }
+
+// RestorePost 恢复文章：POST /api/posts/:id/restore
+func (h *TrashHandler) RestorePost(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.svc.RestorePost(c.Request.Context(), middleware.UID(c), id); err != nil {
+		h.renderError(c, err, "恢复文章失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "恢复成功",
+	})
+}
+
+// RestoreComment 恢复评论：POST /api/comments/:id/restore
+func (h *TrashHandler) RestoreComment(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.svc.RestoreComment(c.Request.Context(), middleware.UID(c), id); err != nil {
+		h.renderError(c, err, "恢复评论失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "恢复成功",
+	})
+}
+
+// PurgePost 永久删除文章：DELETE /api/admin/trash/posts/:id
+func (h *TrashHandler) PurgePost(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.svc.PurgePost(c.Request.Context(), id); err != nil {
+		h.renderError(c, err, "永久删除文章失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "已永久删除",
+	})
+}
+
+// PurgeComment 永久删除评论：DELETE /api/admin/trash/comments/:id
+func (h *TrashHandler) PurgeComment(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.svc.PurgeComment(c.Request.Context(), id); err != nil {
+		h.renderError(c, err, "永久删除评论失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "已永久删除",
+	})
+}
+
+func (h *TrashHandler) listPosts(c *gin.Context, userID *uint) {
+	page, pageSize := util.ParsePage(c)
+	list, total, err := h.svc.ListTrashedPosts(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		h.renderError(c, err, "查询回收站失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code": 0,
+		"data": util.PageResult{
+			Page:     page,
+			PageSize: pageSize,
+			Total:    total,
+			List:     list,
+		},
+	})
+}
+
+func (h *TrashHandler) listComments(c *gin.Context, userID *uint) {
+	page, pageSize := util.ParsePage(c)
+	list, total, err := h.svc.ListTrashedComments(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		h.renderError(c, err, "查询回收站失败")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code": 0,
+		"data": util.PageResult{
+			Page:     page,
+			PageSize: pageSize,
+			Total:    total,
+			List:     list,
+		},
+	})
+}
+
+func (h *TrashHandler) renderError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrNotInTrash):
+		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "回收站中不存在该条目"})
+	case errors.Is(err, service.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "无权恢复"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": message,
+			"detail":  err.Error(),
+		})
+	}
+}
@@ -4,6 +4,7 @@ package handler
 import (
 	"errors"
 	"go-blog/internal/service"
+	"go-blog/internal/util"
 	"net/http"
 	"strconv"
 
@@ -66,3 +67,75 @@ func (h *UserHandler) ListUserPosts(c *gin.Context) {
 		"data":    posts,
 	})
 }
+
+// ExportData 导出当前用户的个人数据：GET /api/me/export
+func (h *UserHandler) ExportData(c *gin.Context) {
+	export, err := h.svc.ExportData(c.Request.Context(), middleware.UID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "导出数据失败",
+			"detail":  err.Error(),
+		})
+		return
+	}
+	c.Header("Content-Disposition", "attachment; filename=go-blog-export.json")
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "ok",
+		"data":    export,
+	})
+}
+
+// BlockUser 屏蔽用户：POST /api/users/:id/block
+func (h *UserHandler) BlockUser(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.svc.BlockUser(c.Request.Context(), middleware.UID(c), id); err != nil {
+		switch {
+		case errors.Is(err, service.ErrBlockSelf):
+			c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "不能屏蔽自己"})
+		case errors.Is(err, service.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "用户不存在"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "屏蔽用户失败", "detail": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"code": 0, "message": "已屏蔽"})
+}
+
+// UnblockUser 取消屏蔽：DELETE /api/users/:id/block
+func (h *UserHandler) UnblockUser(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.svc.UnblockUser(c.Request.Context(), middleware.UID(c), id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "取消屏蔽失败", "detail": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"code": 0, "message": "已取消屏蔽"})
+}
+
+// ListBlocked 我屏蔽的用户：GET /api/me/blocks
+func (h *UserHandler) ListBlocked(c *gin.Context) {
+	page, pageSize := util.ParsePage(c)
+	list, total, err := h.svc.ListBlocked(c.Request.Context(), middleware.UID(c), page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "查询屏蔽列表失败", "detail": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "ok",
+		"data": util.PageResult{
+			Page:     page,
+			PageSize: pageSize,
+			Total:    total,
+			List:     list,
+		},
+	})
+}
@@ -0,0 +1,102 @@
+package importer
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"time"
+)
+
+// DisqusExport Disqus 评论导出文件。
+type DisqusExport struct {
+	Threads []DisqusThread
+	Posts   []DisqusPost
+}
+
+// DisqusThread 讨论串，对应博客中的一篇文章。
+type DisqusThread struct {
+	ID         string // dsq:id
+	Identifier string // 站点在嵌入代码中设置的 disqus_identifier
+	Link       string
+	Title      string
+}
+
+// DisqusPost 一条评论，Parent 为父评论的 dsq:id（为空表示顶层）。
+type DisqusPost struct {
+	ID          string
+	ThreadID    string
+	Parent      string
+	Message     string
+	AuthorName  string
+	AuthorEmail string
+	AuthorUser  string
+	CreatedAt   time.Time
+	IsDeleted   bool
+	IsSpam      bool
+}
+
+// disqusRef 以 dsq:id 属性引用的对象。
+type disqusRef struct {
+	ID string `xml:"http://disqus.com/disqus-internals id,attr"`
+}
+
+type disqusDoc struct {
+	Threads []struct {
+		disqusRef
+		Identifier string `xml:"id"`
+		Link       string `xml:"link"`
+		Title      string `xml:"title"`
+	} `xml:"thread"`
+	Posts []struct {
+		disqusRef
+		Message   string     `xml:"message"`
+		CreatedAt string     `xml:"createdAt"`
+		IsDeleted bool       `xml:"isDeleted"`
+		IsSpam    bool       `xml:"isSpam"`
+		Thread    disqusRef  `xml:"thread"`
+		Parent    *disqusRef `xml:"parent"`
+		Author    struct {
+			Name     string `xml:"name"`
+			Email    string `xml:"email"`
+			Username string `xml:"username"`
+		} `xml:"author"`
+	} `xml:"post"`
+}
+
+// ParseDisqus 解析 Disqus 导出的 XML。
+func ParseDisqus(r io.Reader) (*DisqusExport, error) {
+	var doc disqusDoc
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	out := &DisqusExport{}
+	for _, t := range doc.Threads {
+		out.Threads = append(out.Threads, DisqusThread{
+			ID:         t.ID,
+			Identifier: strings.TrimSpace(t.Identifier),
+			Link:       strings.TrimSpace(t.Link),
+			Title:      strings.TrimSpace(t.Title),
+		})
+	}
+	for _, p := range doc.Posts {
+		post := DisqusPost{
+			ID:          p.ID,
+			ThreadID:    p.Thread.ID,
+			Message:     strings.TrimSpace(p.Message),
+			AuthorName:  strings.TrimSpace(p.Author.Name),
+			AuthorEmail: strings.TrimSpace(p.Author.Email),
+			AuthorUser:  strings.TrimSpace(p.Author.Username),
+			IsDeleted:   p.IsDeleted,
+			IsSpam:      p.IsSpam,
+		}
+		if p.Parent != nil {
+			post.Parent = p.Parent.ID
+		}
+		if t, err := time.Parse(time.RFC3339, strings.TrimSpace(p.CreatedAt)); err == nil {
+			post.CreatedAt = t
+		}
+		out.Posts = append(out.Posts, post)
+	}
+	return out, nil
+}
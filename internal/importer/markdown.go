@@ -0,0 +1,266 @@
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// MarkdownPost 一篇 Markdown 文章（Hugo 风格的 front matter 已解析）。
+type MarkdownPost struct {
+	Path       string // 在导入源中的路径
+	Title      string
+	Date       time.Time
+	Tags       []string
+	Categories []string
+	Draft      bool
+	Slug       string
+	Body       string
+}
+
+// ParseMarkdownFS 遍历文件系统中的 .md/.markdown 文件并解析；单个文件失败不影响其他文件。
+func ParseMarkdownFS(fsys fs.FS) ([]MarkdownPost, map[string]error, error) {
+	var posts []MarkdownPost
+	failed := make(map[string]error)
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			// 跳过隐藏目录与 macOS 打包残留
+			if p != "." && (strings.HasPrefix(d.Name(), ".") || d.Name() == "__MACOSX") {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		ext := strings.ToLower(path.Ext(p))
+		if ext != ".md" && ext != ".markdown" {
+			return nil
+		}
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			failed[p] = err
+			return nil
+		}
+		post, err := ParseMarkdown(p, data)
+		if err != nil {
+			failed[p] = err
+			return nil
+		}
+		posts = append(posts, *post)
+		return nil
+	})
+	return posts, failed, err
+}
+
+// ParseMarkdown 解析单个 Markdown 文件：支持 YAML（---）与 TOML（+++）front matter。
+func ParseMarkdown(p string, data []byte) (*MarkdownPost, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	meta := map[string]any{}
+	body := data
+
+	switch {
+	case bytes.HasPrefix(data, []byte("---")):
+		fm, rest, ok := splitFrontMatter(data, "---")
+		if !ok {
+			return nil, fmt.Errorf("unterminated YAML front matter")
+		}
+		if err := yaml.Unmarshal(fm, &meta); err != nil {
+			return nil, fmt.Errorf("parse YAML front matter: %w", err)
+		}
+		body = rest
+	case bytes.HasPrefix(data, []byte("+++")):
+		fm, rest, ok := splitFrontMatter(data, "+++")
+		if !ok {
+			return nil, fmt.Errorf("unterminated TOML front matter")
+		}
+		if err := toml.Unmarshal(fm, &meta); err != nil {
+			return nil, fmt.Errorf("parse TOML front matter: %w", err)
+		}
+		body = rest
+	}
+
+	post := &MarkdownPost{
+		Path:       p,
+		Title:      stringValue(meta["title"]),
+		Tags:       stringList(meta["tags"]),
+		Categories: stringList(meta["categories"]),
+		Draft:      boolValue(meta["draft"]),
+		Slug:       stringValue(meta["slug"]),
+		Body:       strings.TrimSpace(string(body)),
+	}
+	date, err := timeValue(meta["date"])
+	if err != nil {
+		return nil, err
+	}
+	post.Date = date
+
+	if post.Slug == "" {
+		post.Slug = slugFromPath(p)
+	}
+	if post.Title == "" {
+		post.Title = post.Slug
+	}
+	return post, nil
+}
+
+// splitFrontMatter 按分隔行切分 front matter 与正文。
+func splitFrontMatter(data []byte, delim string) ([]byte, []byte, bool) {
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	if len(lines) == 0 || strings.TrimSpace(string(lines[0])) != delim {
+		return nil, nil, false
+	}
+	offset := len(lines[0])
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(string(line)) == delim {
+			return data[len(lines[0]):offset], data[offset+len(line):], true
+		}
+		offset += len(line)
+	}
+	return nil, nil, false
+}
+
+// slugFromPath 由文件路径推导 slug：page bundle（xxx/index.md）取目录名，否则取文件名。
+func slugFromPath(p string) string {
+	base := strings.TrimSuffix(path.Base(p), path.Ext(p))
+	if base == "index" || base == "_index" {
+		if dir := path.Base(path.Dir(p)); dir != "." && dir != "/" {
+			return dir
+		}
+	}
+	return base
+}
+
+var imageRef = regexp.MustCompile(`!\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+
+// ImageRefs 返回正文中引用的本地图片地址（忽略 http(s) 与 data URI），按出现顺序去重。
+func ImageRefs(body string) []string {
+	seen := map[string]bool{}
+	var refs []string
+	for _, m := range imageRef.FindAllStringSubmatch(body, -1) {
+		ref := m[1]
+		if seen[ref] || isRemote(ref) {
+			continue
+		}
+		seen[ref] = true
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// ResolveImage 将图片引用解析为导入源中的路径：相对路径基于文章所在目录，
+// 绝对路径依次尝试 Hugo 的 static/ 目录与源根目录。
+func ResolveImage(fsys fs.FS, postPath, ref string) (string, bool) {
+	ref = strings.SplitN(strings.SplitN(ref, "#", 2)[0], "?", 2)[0]
+	var candidates []string
+	if strings.HasPrefix(ref, "/") {
+		clean := strings.TrimPrefix(path.Clean(ref), "/")
+		candidates = []string{path.Join("static", clean), clean}
+	} else {
+		candidates = []string{path.Join(path.Dir(postPath), ref)}
+	}
+	for _, c := range candidates {
+		if !fs.ValidPath(c) {
+			continue
+		}
+		if info, err := fs.Stat(fsys, c); err == nil && !info.IsDir() {
+			return c, true
+		}
+	}
+	return "", false
+}
+
+func isRemote(ref string) bool {
+	lower := strings.ToLower(ref)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") ||
+		strings.HasPrefix(lower, "//") || strings.HasPrefix(lower, "data:")
+}
+
+func stringValue(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(t)
+	default:
+		return strings.TrimSpace(fmt.Sprint(t))
+	}
+}
+
+func stringList(v any) []string {
+	var out []string
+	switch t := v.(type) {
+	case nil:
+	case []any:
+		for _, item := range t {
+			if s := stringValue(item); s != "" {
+				out = append(out, s)
+			}
+		}
+	case []string:
+		for _, s := range t {
+			if s = strings.TrimSpace(s); s != "" {
+				out = append(out, s)
+			}
+		}
+	default:
+		// 单个字符串，允许逗号分隔
+		for _, s := range strings.Split(stringValue(t), ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
+
+func boolValue(v any) bool {
+	switch t := v.(type) {
+	case bool:
+		return t
+	case string:
+		b, _ := strconv.ParseBool(strings.TrimSpace(t))
+		return b
+	}
+	return false
+}
+
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// timeValue 解析 front matter 中的日期，缺省返回零值。
+func timeValue(v any) (time.Time, error) {
+	switch t := v.(type) {
+	case nil:
+		return time.Time{}, nil
+	case time.Time:
+		return t, nil
+	case toml.LocalDateTime:
+		return t.AsTime(time.Local), nil
+	case toml.LocalDate:
+		return t.AsTime(time.Local), nil
+	case string:
+		s := strings.TrimSpace(t)
+		for _, layout := range dateLayouts {
+			if d, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+				return d, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("unrecognized date %q", s)
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date %v", v)
+}
@@ -0,0 +1,47 @@
+// Package importer 解析外部博客的导出数据（Markdown、WordPress、Disqus），只负责读取与转换，不访问数据库。
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// OpenSource 打开导入源：目录或 .zip 文件，返回只读文件系统及关闭函数。
+func OpenSource(path string) (fs.FS, func() error, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if info.IsDir() {
+		return os.DirFS(path), func() error { return nil }, nil
+	}
+	if !strings.EqualFold(pathExt(path), ".zip") {
+		return nil, nil, fmt.Errorf("unsupported import source %q: want a directory or .zip", path)
+	}
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return zr, zr.Close, nil
+}
+
+// ZipFS 将内存中的 ZIP 数据包装为文件系统（用于 HTTP 上传）。
+func ZipFS(r io.Reader) (fs.FS, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return zip.NewReader(bytes.NewReader(data), int64(len(data)))
+}
+
+func pathExt(p string) string {
+	if i := strings.LastIndexByte(p, '.'); i >= 0 {
+		return p[i:]
+	}
+	return ""
+}
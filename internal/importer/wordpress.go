@@ -0,0 +1,220 @@
+package importer
+
+import (
+	"encoding/xml"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// WordPress 导出（WXR）中的文章类型与分类法。
+const (
+	WPTypePost = "post"
+	WPTypePage = "page"
+
+	wpDomainCategory = "category"
+	wpDomainTag      = "post_tag"
+)
+
+// WXR WordPress 导出文件（RSS 2.0 + wp 扩展）。
+type WXR struct {
+	Authors    []WPAuthor
+	Categories []WPTerm
+	Tags       []WPTerm
+	Items      []WPItem
+}
+
+// WPAuthor 导出中的作者。
+type WPAuthor struct {
+	ID          string `xml:"author_id"`
+	Login       string `xml:"author_login"`
+	Email       string `xml:"author_email"`
+	DisplayName string `xml:"author_display_name"`
+}
+
+// WPTerm 分类或标签。
+type WPTerm struct {
+	Name   string
+	Slug   string
+	Parent string // 父分类的 slug（仅分类）
+}
+
+// WPItem 文章或页面（附件等其他类型由调用方忽略）。
+type WPItem struct {
+	ID         string
+	Type       string
+	Title      string
+	Slug       string
+	Status     string
+	Password   string // 文章访问密码，空表示未设置
+	Creator    string // 作者 login
+	Content    string
+	Date       time.Time
+	Categories []WPTerm
+	Tags       []WPTerm
+	Comments   []WPComment
+}
+
+// WPComment 文章下的评论，Parent 为父评论的原始 ID（"0" 表示顶层）。
+type WPComment struct {
+	ID          string
+	Parent      string
+	Author      string
+	AuthorEmail string
+	UserID      string // 站内注册用户的原始 ID，"0" 表示访客
+	Content     string
+	Approved    string // "1" 已通过，"0" 待审，"spam"、"trash"
+	Type        string // 空或 "comment" 为普通评论，另有 pingback/trackback
+	Date        time.Time
+}
+
+// IsApproved 评论是否已通过审核。
+func (c WPComment) IsApproved() bool { return c.Approved == "1" || c.Approved == "approved" }
+
+// IsPing 是否为 pingback/trackback。
+func (c WPComment) IsPing() bool { return c.Type == "pingback" || c.Type == "trackback" }
+
+// 以下为 XML 映射结构。wp:* 元素按本地名匹配，兼容 1.0~1.2 各版本的命名空间；
+// content:encoded 与 excerpt:encoded 本地名相同，因此按命名空间区分。
+type wxrDoc struct {
+	Channel struct {
+		Authors    []WPAuthor   `xml:"author"`
+		Categories []wxrCatElem `xml:"category"`
+		Tags       []wxrTagElem `xml:"tag"`
+		Items      []wxrItem    `xml:"item"`
+	} `xml:"channel"`
+}
+
+type wxrCatElem struct {
+	Name   string `xml:"cat_name"`
+	Slug   string `xml:"category_nicename"`
+	Parent string `xml:"category_parent"`
+}
+
+type wxrTagElem struct {
+	Name string `xml:"tag_name"`
+	Slug string `xml:"tag_slug"`
+}
+
+type wxrItem struct {
+	Title      string `xml:"title"`
+	Creator    string `xml:"creator"`
+	Content    string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	PostID     string `xml:"post_id"`
+	DateGMT    string `xml:"post_date_gmt"`
+	Date       string `xml:"post_date"`
+	Name       string `xml:"post_name"`
+	Status     string `xml:"status"`
+	Password   string `xml:"post_password"`
+	Type       string `xml:"post_type"`
+	Categories []struct {
+		Domain   string `xml:"domain,attr"`
+		Nicename string `xml:"nicename,attr"`
+		Name     string `xml:",chardata"`
+	} `xml:"category"`
+	Comments []struct {
+		ID          string `xml:"comment_id"`
+		Author      string `xml:"comment_author"`
+		AuthorEmail string `xml:"comment_author_email"`
+		DateGMT     string `xml:"comment_date_gmt"`
+		Date        string `xml:"comment_date"`
+		Content     string `xml:"comment_content"`
+		Approved    string `xml:"comment_approved"`
+		Type        string `xml:"comment_type"`
+		Parent      string `xml:"comment_parent"`
+		UserID      string `xml:"comment_user_id"`
+	} `xml:"comment"`
+}
+
+// ParseWXR 解析 WordPress 导出文件。
+func ParseWXR(r io.Reader) (*WXR, error) {
+	var doc wxrDoc
+	dec := xml.NewDecoder(r)
+	dec.Strict = false
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	out := &WXR{}
+	for _, a := range doc.Channel.Authors {
+		if a.Login = strings.TrimSpace(a.Login); a.Login != "" {
+			a.Email = strings.TrimSpace(a.Email)
+			a.DisplayName = strings.TrimSpace(a.DisplayName)
+			out.Authors = append(out.Authors, a)
+		}
+	}
+	// channel 下的普通 RSS <category> 没有 cat_name，借此与 wp:category 区分
+	for _, c := range doc.Channel.Categories {
+		if name := strings.TrimSpace(c.Name); name != "" {
+			out.Categories = append(out.Categories, WPTerm{Name: name, Slug: wpSlug(c.Slug), Parent: wpSlug(c.Parent)})
+		}
+	}
+	for _, t := range doc.Channel.Tags {
+		if name := strings.TrimSpace(t.Name); name != "" {
+			out.Tags = append(out.Tags, WPTerm{Name: name, Slug: wpSlug(t.Slug)})
+		}
+	}
+
+	for _, it := range doc.Channel.Items {
+		item := WPItem{
+			ID:       strings.TrimSpace(it.PostID),
+			Type:     strings.TrimSpace(it.Type),
+			Title:    strings.TrimSpace(it.Title),
+			Slug:     wpSlug(it.Name),
+			Status:   strings.TrimSpace(it.Status),
+			Password: it.Password,
+			Creator:  strings.TrimSpace(it.Creator),
+			Content:  it.Content,
+			Date:     wpTime(it.DateGMT, it.Date),
+		}
+		for _, c := range it.Categories {
+			term := WPTerm{Name: strings.TrimSpace(c.Name), Slug: wpSlug(c.Nicename)}
+			if term.Name == "" {
+				continue
+			}
+			switch c.Domain {
+			case wpDomainCategory:
+				item.Categories = append(item.Categories, term)
+			case wpDomainTag:
+				item.Tags = append(item.Tags, term)
+			}
+		}
+		for _, c := range it.Comments {
+			item.Comments = append(item.Comments, WPComment{
+				ID:          strings.TrimSpace(c.ID),
+				Parent:      strings.TrimSpace(c.Parent),
+				Author:      strings.TrimSpace(c.Author),
+				AuthorEmail: strings.TrimSpace(c.AuthorEmail),
+				UserID:      strings.TrimSpace(c.UserID),
+				Content:     c.Content,
+				Approved:    strings.TrimSpace(c.Approved),
+				Type:        strings.TrimSpace(c.Type),
+				Date:        wpTime(c.DateGMT, c.Date),
+			})
+		}
+		out.Items = append(out.Items, item)
+	}
+	return out, nil
+}
+
+// wpTime 优先使用 GMT 时间；草稿的 GMT 时间可能为 0000-00-00，此时退回站点本地时间。
+func wpTime(gmt, local string) time.Time {
+	const layout = "2006-01-02 15:04:05"
+	if t, err := time.ParseInLocation(layout, strings.TrimSpace(gmt), time.UTC); err == nil && t.Year() > 1 {
+		return t
+	}
+	if t, err := time.ParseInLocation(layout, strings.TrimSpace(local), time.Local); err == nil && t.Year() > 1 {
+		return t
+	}
+	return time.Time{}
+}
+
+// wpSlug WordPress 将非 ASCII 的 slug 以百分号编码保存，这里还原为原文。
+func wpSlug(s string) string {
+	s = strings.TrimSpace(s)
+	if u, err := url.PathUnescape(s); err == nil {
+		return u
+	}
+	return s
+}
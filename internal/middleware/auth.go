@@ -41,6 +41,19 @@ func GenerateToken(userId uint) (string, error) {
 	return token.SignedString(jwtSecret())
 }
 
+// QueryToken 允许用 access_token 查询参数携带令牌（浏览器 EventSource 无法设置请求头），
+// 需放在 AuthMiddleware 之前，仅用于 SSE 路由；请求已带 Authorization 时不生效。
+func QueryToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			if token := c.Query("access_token"); token != "" {
+				c.Request.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		c.Next()
+	}
+}
+
 // AuthMiddleware 校验 Authorization: Bearer <token>
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -43,3 +43,13 @@ func UID(c *gin.Context) uint {
 	}
 	return 0
 }
+
+// Role 从上下文返回当前用户角色（由 AuthMiddleware 设置），缺失时为空串。
+func Role(c *gin.Context) string {
+	if v, ok := c.Get("role"); ok {
+		if role, ok := v.(string); ok {
+			return role
+		}
+	}
+	return ""
+}
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// 阅读清单可见性。
+const (
+	ReadingListPrivate = "private"
+	ReadingListPublic  = "public"
+)
+
+// Bookmark 表示用户收藏的文章，可归入某个阅读清单。
+type Bookmark struct {
+	Id            uint      `json:"id" gorm:"primaryKey"`
+	UserId        uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_bookmark_user_post,priority:1"`
+	PostId        uint      `json:"post_id" gorm:"not null;uniqueIndex:idx_bookmark_user_post,priority:2;index"`
+	ReadingListId *uint     `json:"reading_list_id,omitempty" gorm:"index"`
+	Post          Post      `json:"-" gorm:"foreignKey:PostId"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ReadingList 表示用户的阅读清单，公开清单可通过分享令牌访问。
+type ReadingList struct {
+	Id          uint      `json:"id" gorm:"primaryKey"`
+	UserId      uint      `json:"user_id" gorm:"index;not null"`
+	Name        string    `json:"name" gorm:"type:varchar(100);not null"`
+	Description string    `json:"description" gorm:"type:varchar(500)"`
+	Visibility  string    `json:"visibility" gorm:"type:varchar(16);not null;default:'private'"` // private / public
+	ShareToken  string    `json:"share_token" gorm:"type:varchar(64);not null;uniqueIndex"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -1,14 +1,48 @@
 package model
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-blog/internal/util"
+	"gorm.io/gorm"
+)
+
+// 评论审核状态。
+const (
+	CommentStatusPending  = "pending"  // 待审核，仅作者本人可见
+	CommentStatusApproved = "approved" // 已通过
+	CommentStatusSpam     = "spam"     // 垃圾评论
+	CommentStatusRejected = "rejected" // 已拒绝
+)
+
+// 评论审核策略（站点默认或按文章设置）。
+const (
+	CommentModerationAll       = "all"        // 所有评论先审后发
+	CommentModerationFirstTime = "first_time" // 仅首次评论（尚无通过的评论）需审核
+	CommentModerationNone      = "none"       // 不审核，直接发布
+)
+
+// DeletedCommentContent 占位评论对外展示的内容。
+const DeletedCommentContent = "[deleted]"
 
 // Comment 表示文章下的评论，支持自引用回复。
 type Comment struct {
-	Id       uint   `json:"id" gorm:"primaryKey"`
-	Content  string `json:"content" gorm:"type:longtext;not null"`
-	UserId   uint   `json:"user_id" gorm:"index;not null"`
-	PostId   uint   `json:"post_id" gorm:"index;not null"`
-	ParentId *uint  `json:"parent_id" gorm:"index"`
+	Id        uint       `json:"id" gorm:"primaryKey"`
+	Content   string     `json:"content" gorm:"type:longtext;not null"`
+	UserId    uint       `json:"user_id" gorm:"index;not null"`
+	PostId    uint       `json:"post_id" gorm:"index;not null"`
+	ParentId  *uint      `json:"parent_id" gorm:"index"`
+	Path      string     `json:"-" gorm:"type:varchar(255);not null;default:'';index"`             // 物化路径：祖先到自身的定长ID，如 "0000000001/0000000005/"
+	Depth     int        `json:"depth" gorm:"not null;default:0"`                                  // 层级，顶层评论为 0
+	Status    string     `json:"status" gorm:"type:varchar(20);not null;default:'approved';index"` // pending / approved / spam / rejected
+	IP        string     `json:"-" gorm:"type:varchar(45)"`                                        // 提交时的客户端 IP，供垃圾检测与训练使用
+	UserAgent string     `json:"-" gorm:"type:varchar(255)"`                                       // 提交时的 User-Agent
+	SpamLabel string     `json:"-" gorm:"type:varchar(8);not null;default:''"`                     // 已用于训练垃圾分类器的标签：spam / ham
+	Tombstone bool       `json:"tombstone" gorm:"not null;default:false"`                          // 已删除但仍有回复：保留为占位，隐藏内容与作者
+	EditedAt  *time.Time `json:"edited_at,omitempty"`                                              // 最后一次编辑时间，未编辑过为空
 
 	// 关联用户和文章
 	User    User      `json:"-" gorm:"foreignKey:UserId"`
@@ -17,4 +51,37 @@ type Comment struct {
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"` // 软删除（回收站）
+
+	Mentions []MentionRef `json:"mentions,omitempty" gorm:"-"` // 由服务层填充
+}
+
+// VisibleTo 判断评论对用户是否可见：已通过的评论所有人可见，其余仅作者本人可见。
+func (c *Comment) VisibleTo(uid uint) bool {
+	return c.Status == CommentStatusApproved || c.UserId == uid
+}
+
+// LoadCommentMaxDepth 读取 COMMENT_MAX_DEPTH（默认 5，取值 1~20，受物化路径长度限制）。
+func LoadCommentMaxDepth() int {
+	n := util.EnvInt("COMMENT_MAX_DEPTH", 5)
+	if n < 1 || n > 20 {
+		n = 5
+	}
+	return n
+}
+
+// CommentPathSegment 返回评论在物化路径中的片段（定长ID，保证按路径排序即为先序遍历）。
+func CommentPathSegment(id uint) string {
+	return fmt.Sprintf("%010d/", id)
+}
+
+// PathIDs 按从根到自身的顺序返回路径上的评论ID。
+func (c *Comment) PathIDs() []uint {
+	var ids []uint
+	for _, seg := range strings.Split(strings.TrimSuffix(c.Path, "/"), "/") {
+		if id, err := strconv.ParseUint(seg, 10, 64); err == nil {
+			ids = append(ids, uint(id))
+		}
+	}
+	return ids
 }
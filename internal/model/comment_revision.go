@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// CommentRevision 评论的历史版本：每次编辑前保存被替换的内容。
+type CommentRevision struct {
+	Id        uint      `json:"id" gorm:"primaryKey"`
+	CommentId uint      `json:"comment_id" gorm:"index;not null"`
+	EditorId  uint      `json:"editor_id" gorm:"not null"` // 本次编辑的操作人（作者或审核员）
+	Editor    *User     `json:"editor,omitempty" gorm:"foreignKey:EditorId"`
+	Content   string    `json:"content" gorm:"type:longtext;not null"` // 编辑前的内容
+	CreatedAt time.Time `json:"created_at"`                            // 编辑时间
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCommentPathSegment(t *testing.T) {
+	if got := CommentPathSegment(42); got != "0000000042/" {
+		t.Fatalf("CommentPathSegment(42) = %q", got)
+	}
+	// 定长片段保证按字符串排序即按ID排序
+	if CommentPathSegment(9) >= CommentPathSegment(10) {
+		t.Fatal("segments must sort numerically")
+	}
+}
+
+func TestCommentPathIDs(t *testing.T) {
+	tests := []struct {
+		path string
+		want []uint
+	}{
+		{"", nil},
+		{"0000000001/", []uint{1}},
+		{"0000000001/0000000005/0000000012/", []uint{1, 5, 12}},
+		{"0000000001/0000000005", []uint{1, 5}},
+	}
+	for _, tt := range tests {
+		c := Comment{Path: tt.path}
+		if got := c.PathIDs(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("PathIDs(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestCommentPathFitsColumn(t *testing.T) {
+	// 最大层级下路径（depth+1 段）不能超出 varchar(255)
+	path := strings.Repeat(CommentPathSegment(4294967295), 20+1)
+	if len(path) > 255 {
+		t.Fatalf("path of max depth is %d chars, exceeds 255", len(path))
+	}
+}
+
+func TestLoadCommentMaxDepth(t *testing.T) {
+	tests := []struct {
+		env  string
+		want int
+	}{
+		{"", 5},
+		{"1", 1},
+		{"20", 20},
+		{"21", 5},
+		{"0", 5},
+		{"abc", 5},
+	}
+	for _, tt := range tests {
+		t.Setenv("COMMENT_MAX_DEPTH", tt.env)
+		if got := LoadCommentMaxDepth(); got != tt.want {
+			t.Errorf("COMMENT_MAX_DEPTH=%q: got %d, want %d", tt.env, got, tt.want)
+		}
+	}
+}
@@ -20,12 +20,12 @@ func InitDB() {
 
 	if appEnv != "production" {
 		if err := godotenv.Load(); err == nil {
-			fmt.Println("已加载 .env配置文件")
+			log.Println("已加载 .env配置文件")
 		} else {
-			fmt.Println("未找到 .env 文件")
+			log.Println("未找到 .env 文件")
 		}
 	} else {
-		fmt.Println("生产环境：从系统环境变量读取配置")
+		log.Println("生产环境：从系统环境变量读取配置")
 	}
 
 	host := getEnv("DB_HOST", "127.0.0.1")
@@ -38,13 +38,16 @@ func InitDB() {
 	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=true&loc=Local",
 		user, pass, host, port, name)
 
-	//GORM 日志配置：开发打印 SQL，生产只打印错误
-	var gormLogger logger.Interface
+	//GORM 日志配置：开发打印 SQL，生产只打印错误；输出到 stderr，避免混入命令行工具的 JSON 输出
+	level := logger.Info
 	if appEnv == "production" {
-		gormLogger = logger.Default.LogMode(logger.Error)
-	} else {
-		gormLogger = logger.Default.LogMode(logger.Info)
+		level = logger.Error
 	}
+	gormLogger := logger.New(log.New(os.Stderr, "\r\n", log.LstdFlags), logger.Config{
+		SlowThreshold: 200 * time.Millisecond,
+		LogLevel:      level,
+		Colorful:      true,
+	})
 
 	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
 		Logger: gormLogger,
@@ -52,7 +55,7 @@ func InitDB() {
 	if err != nil {
 		log.Fatalf("数据库连接失败 %v", err)
 	}
-	fmt.Println("数据库连接成功")
+	log.Println("数据库连接成功")
 
 	//设置连接池
 	sqlDB, err := db.DB()
@@ -72,9 +75,73 @@ func InitDB() {
 		PostTag{},
 		Comment{},
 		Category{},
+		Reaction{},
+		ReactionCount{},
+		Bookmark{},
+		ReadingList{},
+		Series{},
+		SeriesPost{},
+		PostAuthor{},
+		PostViewer{},
+		PreviewLink{},
+		PostTransition{},
+		ImportRecord{},
+		SpamToken{},
+		CommentRevision{},
+		Mention{},
+		UserBlock{},
+		Notification{},
+		NotificationActor{},
+		NotificationPreference{},
 	); err != nil {
 		log.Fatalf("auto migrate error: %v", err)
 	}
+
+	if err := backfillPostOwners(DB); err != nil {
+		log.Fatalf("backfill post authors error: %v", err)
+	}
+	if err := backfillCommentPaths(DB, LoadCommentMaxDepth()); err != nil {
+		log.Fatalf("backfill comment paths error: %v", err)
+	}
+}
+
+// backfillPostOwners 为尚无所有者记录的历史文章补齐 post_authors 中的 owner 行。
+func backfillPostOwners(db *gorm.DB) error {
+	return db.Exec(`INSERT INTO post_authors (post_id, user_id, role, created_at)
+SELECT p.id, p.user_id, ?, p.created_at FROM posts p
+LEFT JOIN post_authors pa ON pa.post_id = p.id AND pa.user_id = p.user_id
+WHERE pa.post_id IS NULL`, PostAuthorOwner).Error
+}
+
+// backfillCommentPaths 为尚无物化路径的历史评论逐层补齐 path 与 depth（含回收站中的评论）；
+// 父评论已不存在的评论按顶层处理。超过 maxDepth 的历史回复与新回复一样挂到第 maxDepth-1 层的祖先下，
+// 避免过深的链条超出 path 的长度。
+func backfillCommentPaths(db *gorm.DB, maxDepth int) error {
+	if err := db.Exec(`UPDATE comments SET path = CONCAT(LPAD(id, 10, '0'), '/'), depth = 0
+WHERE path = '' AND (parent_id IS NULL OR parent_id NOT IN (SELECT id FROM (SELECT id FROM comments) p))`).Error; err != nil {
+		return err
+	}
+	seg := len(CommentPathSegment(0))
+	for {
+		res := db.Exec(`UPDATE comments c JOIN comments p ON c.parent_id = p.id
+SET c.path = CONCAT(p.path, LPAD(c.id, 10, '0'), '/'), c.depth = p.depth + 1
+WHERE c.path = '' AND p.path <> '' AND p.depth < ?`, maxDepth)
+		if res.Error != nil {
+			return res.Error
+		}
+		// 父评论已在最深层：改挂到其路径上第 maxDepth-1 层的祖先
+		flat := db.Exec(`UPDATE comments c JOIN comments p ON c.parent_id = p.id
+SET c.parent_id = CAST(SUBSTRING(p.path, ?, ?) AS UNSIGNED),
+	c.path = CONCAT(LEFT(p.path, ?), LPAD(c.id, 10, '0'), '/'), c.depth = ?
+WHERE c.path = '' AND p.path <> '' AND p.depth >= ?`,
+			(maxDepth-1)*seg+1, seg-1, maxDepth*seg, maxDepth, maxDepth)
+		if flat.Error != nil {
+			return flat.Error
+		}
+		if res.RowsAffected+flat.RowsAffected == 0 {
+			return nil
+		}
+	}
 }
 
 // getEnv 读取环境变量，若不存在则返回默认值。
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// 导入来源。
+const (
+	ImportSourceWordPress = "wordpress"
+	ImportSourceDisqus    = "disqus"
+)
+
+// 导入记录对应的本地对象类型。
+const (
+	ImportKindPost    = "post"
+	ImportKindComment = "comment"
+	ImportKindUser    = "user"
+)
+
+// ImportRecord 记录外部数据（来源 + 类型 + 原始 ID）与本地记录的对应关系，使重复导入变为更新而非新建。
+type ImportRecord struct {
+	Id         uint      `json:"id" gorm:"primaryKey"`
+	Source     string    `json:"source" gorm:"type:varchar(20);not null;uniqueIndex:idx_import_record,priority:1"`
+	Kind       string    `json:"kind" gorm:"type:varchar(20);not null;uniqueIndex:idx_import_record,priority:2"`
+	ExternalId string    `json:"external_id" gorm:"type:varchar(191);not null;uniqueIndex:idx_import_record,priority:3"`
+	LocalId    uint      `json:"local_id" gorm:"not null;index"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+// 提及来源类型。
+const (
+	MentionTargetPost    = "post"
+	MentionTargetComment = "comment"
+)
+
+// Mention 表示文章或评论中对某个用户的 @提及，每个来源每个用户一条。
+// NotifiedAt 为空表示尚未通知（来源未公开，如待审核评论或草稿）。
+type Mention struct {
+	Id         uint       `json:"id" gorm:"primaryKey"`
+	TargetType string     `json:"target_type" gorm:"type:varchar(16);not null;uniqueIndex:idx_mention_unique,priority:1"`
+	TargetId   uint       `json:"target_id" gorm:"not null;uniqueIndex:idx_mention_unique,priority:2"`
+	UserId     uint       `json:"user_id" gorm:"not null;uniqueIndex:idx_mention_unique,priority:3;index"`
+	Username   string     `json:"username" gorm:"size:64;not null"`
+	AuthorId   uint       `json:"author_id" gorm:"not null"`
+	NotifiedAt *time.Time `json:"notified_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// MentionRef 响应中的提及实体，客户端据此将正文中的 @username 渲染为链接。
+type MentionRef struct {
+	UserId   uint   `json:"user_id"`
+	Username string `json:"username"`
+	URL      string `json:"url"`
+}
+
+// Ref 转换为响应中的提及实体，链接指向该用户的文章列表。
+func (m Mention) Ref() MentionRef {
+	return MentionRef{
+		UserId:   m.UserId,
+		Username: m.Username,
+		URL:      "/api/users/" + strconv.FormatUint(uint64(m.UserId), 10) + "/posts",
+	}
+}
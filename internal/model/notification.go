@@ -0,0 +1,50 @@
+package model
+
+import "time"
+
+// 通知类型。
+const (
+	NotificationReply      = "reply"      // 回复了我的评论
+	NotificationComment    = "comment"    // 评论了我的文章
+	NotificationMention    = "mention"    // 在文章或评论中提到了我
+	NotificationReaction   = "reaction"   // 对我的文章或评论表态
+	NotificationModeration = "moderation" // 我的评论或文章的审核结果
+)
+
+// NotificationTypes 全部通知类型，用于偏好设置。
+var NotificationTypes = []string{
+	NotificationReply, NotificationComment, NotificationMention, NotificationReaction, NotificationModeration,
+}
+
+// Notification 表示发给用户的一条站内通知。相同 GroupKey 的未读事件合并为一条：
+// Count 为合并的事件数，ActorCount 为去重后的触发人数，ActorId 为最近一次的触发人（0 表示系统）。
+type Notification struct {
+	Id         uint       `json:"id" gorm:"primaryKey"`
+	UserId     uint       `json:"user_id" gorm:"not null;index:idx_notification_user,priority:1;index:idx_notification_group,priority:1"`
+	Type       string     `json:"type" gorm:"type:varchar(16);not null"`
+	GroupKey   string     `json:"-" gorm:"type:varchar(64);not null;index:idx_notification_group,priority:2"` // 合并键，如 "reaction:post:12"
+	TargetType string     `json:"target_type" gorm:"type:varchar(16);not null"`                               // post / comment
+	TargetId   uint       `json:"target_id" gorm:"not null"`
+	PostId     uint       `json:"post_id" gorm:"not null"` // 所属文章，便于客户端跳转
+	ActorId    uint       `json:"actor_id" gorm:"not null;default:0"`
+	Actor      *User      `json:"-" gorm:"foreignKey:ActorId"`
+	ActorCount int64      `json:"actor_count" gorm:"not null;default:0"`
+	Count      int64      `json:"count" gorm:"not null;default:1"`
+	Detail     string     `json:"detail" gorm:"type:varchar(255);not null;default:''"` // 评论摘要、表情或审核结果
+	ReadAt     *time.Time `json:"read_at,omitempty" gorm:"index:idx_notification_user,priority:2"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"index"` // 合并新事件时刷新，列表按此倒序
+}
+
+// NotificationActor 记录参与过某条合并通知的用户，用于统计去重人数。
+type NotificationActor struct {
+	NotificationId uint `gorm:"primaryKey"`
+	UserId         uint `gorm:"primaryKey"`
+}
+
+// NotificationPreference 用户对某类通知的开关，没有记录时视为开启。
+type NotificationPreference struct {
+	UserId  uint   `json:"user_id" gorm:"primaryKey"`
+	Type    string `json:"type" gorm:"type:varchar(16);primaryKey"`
+	Enabled bool   `json:"enabled" gorm:"not null"`
+}
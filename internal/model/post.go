@@ -1,18 +1,54 @@
 package model
 
-import "time"
+import (
+	"time"
 
-// Post 表示文章模型（每篇文章属于一个用户）
+	"gorm.io/gorm"
+)
+
+// 文章状态（审核流程：draft → in_review → approved → published）。
+const (
+	PostStatusDraft     = "draft"
+	PostStatusInReview  = "in_review"
+	PostStatusApproved  = "approved"
+	PostStatusPublished = "published"
+)
+
+// 文章可见性。
+const (
+	PostVisibilityPublic   = "public"   // 公开
+	PostVisibilityPrivate  = "private"  // 私密：仅作者与指定读者
+	PostVisibilityUnlisted = "unlisted" // 不公开：凭链接访问，不出现在列表与搜索中
+	PostVisibilityPassword = "password" // 密码保护：列表中隐藏正文，凭密码阅读
+)
+
+// Post 表示文章模型（每篇文章属于一个所有者用户，可有多位合著者）
 type Post struct {
-	ID         uint      `json:"id" gorm:"primaryKey"`
-	Title      string    `json:"title"   gorm:"size:200;not null"`
-	Content    string    `json:"content" gorm:"type:longtext"`
-	UserID     uint      `json:"user_id" gorm:"index;not null"` // 外键
-	User       *User     `json:"user,omitempty" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
-	CategoryId uint      `json:"category_id" gorm:"index"`
-	Category   Category  `json:"category" gorm:"foreignKey:CategoryId"`
-	Tags       []Tag     `json:"tags,omitempty" gorm:"many2many:post_tags"`
-	Status     string    `json:"status" gorm:"type:varchar(20);default:'draft';index"` // draft / published
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID                uint           `json:"id" gorm:"primaryKey"`
+	Title             string         `json:"title"   gorm:"size:200;not null"`
+	Content           string         `json:"content" gorm:"type:longtext"`
+	Slug              string         `json:"slug,omitempty" gorm:"size:200;index"` // 外部导入时的原始 slug，用于重复导入时定位文章
+	UserID            uint           `json:"user_id" gorm:"index;not null"`        // 外键
+	User              *User          `json:"user,omitempty" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CategoryId        uint           `json:"category_id" gorm:"index"`
+	Category          Category       `json:"category" gorm:"foreignKey:CategoryId"`
+	Tags              []Tag          `json:"tags,omitempty" gorm:"many2many:post_tags"`
+	Authors           []PostAuthor   `json:"authors,omitempty" gorm:"foreignKey:PostId"`
+	Status            string         `json:"status" gorm:"type:varchar(20);default:'draft';index"`           // draft / published
+	Visibility        string         `json:"visibility" gorm:"type:varchar(20);default:'public';index"`      // public / private / unlisted / password
+	PasswordHash      string         `json:"-" gorm:"size:100"`                                              // 密码保护文章的 bcrypt 哈希
+	CommentModeration string         `json:"comment_moderation" gorm:"type:varchar(20);not null;default:''"` // 评论审核策略：all / first_time / none，空表示沿用站点设置
+	ViewCount         int64          `json:"view_count" gorm:"not null;default:0"`                           // 浏览量（批量落库）
+	LikeCount         int64          `json:"like_count" gorm:"not null;default:0"`                           // 点赞数
+	HotScore          float64        `json:"hot_score" gorm:"not null;default:0;index"`                      // 热度分（定期重算）
+	Version           uint64         `json:"version" gorm:"not null;default:1"`                              // 乐观锁版本号，每次编辑递增
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"` // 软删除（回收站）
+
+	// 以下字段不落库，由服务层按当前用户填充
+	Reactions   map[string]int64 `json:"reactions,omitempty" gorm:"-"`
+	MyReactions []string         `json:"my_reactions,omitempty" gorm:"-"`
+	Series      *SeriesNav       `json:"series,omitempty" gorm:"-"`
+	Mentions    []MentionRef     `json:"mentions,omitempty" gorm:"-"`
 }
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// 文章作者角色。
+const (
+	PostAuthorOwner    = "owner"    // 所有者：可编辑、删除、邀请/移除合著者
+	PostAuthorCoAuthor = "coauthor" // 合著者：可编辑，不可删除
+)
+
+// PostAuthor 文章与作者的关联（含角色），所有者与 posts.user_id 一致。
+type PostAuthor struct {
+	PostId    uint      `json:"post_id" gorm:"primaryKey"`
+	UserId    uint      `json:"user_id" gorm:"primaryKey;index"`
+	Role      string    `json:"role" gorm:"type:varchar(16);not null;default:'coauthor'"`
+	User      *User     `json:"user,omitempty" gorm:"foreignKey:UserId"`
+	CreatedAt time.Time `json:"created_at"`
+}
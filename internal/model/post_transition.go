@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// PostTransition 记录文章的每一次状态变更（含审核意见）。
+type PostTransition struct {
+	Id         uint      `json:"id" gorm:"primaryKey"`
+	PostId     uint      `json:"post_id" gorm:"index;not null"`
+	UserId     uint      `json:"user_id" gorm:"index;not null"` // 操作人
+	User       *User     `json:"user,omitempty" gorm:"foreignKey:UserId"`
+	FromStatus string    `json:"from_status" gorm:"type:varchar(20)"` // 创建时为空
+	ToStatus   string    `json:"to_status" gorm:"type:varchar(20);not null"`
+	Comment    string    `json:"comment" gorm:"type:varchar(1000)"`
+	CreatedAt  time.Time `json:"created_at"`
+}
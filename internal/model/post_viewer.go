@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// PostViewer 私密文章的指定读者。
+type PostViewer struct {
+	PostId    uint      `json:"post_id" gorm:"primaryKey"`
+	UserId    uint      `json:"user_id" gorm:"primaryKey;index"`
+	CreatedAt time.Time `json:"created_at"`
+}
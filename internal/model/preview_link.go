@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// PreviewLink 草稿预览链接：令牌本身经签名下发，库中仅记录 jti 以便列出与撤销。
+type PreviewLink struct {
+	Id        uint       `json:"id" gorm:"primaryKey"`
+	PostId    uint       `json:"post_id" gorm:"index;not null"`
+	UserId    uint       `json:"user_id" gorm:"index;not null"` // 创建者
+	TokenId   string     `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"index"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
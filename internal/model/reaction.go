@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// 表态目标类型。
+const (
+	ReactionTargetPost    = "post"
+	ReactionTargetComment = "comment"
+)
+
+// Reaction 表示用户对文章或评论的一次表态（点赞或表情），每人每目标每种表情唯一。
+type Reaction struct {
+	Id         uint      `json:"id" gorm:"primaryKey"`
+	UserId     uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_reaction_unique,priority:1"`
+	TargetType string    `json:"target_type" gorm:"type:varchar(16);not null;uniqueIndex:idx_reaction_unique,priority:2;index:idx_reaction_target,priority:1"`
+	TargetId   uint      `json:"target_id" gorm:"not null;uniqueIndex:idx_reaction_unique,priority:3;index:idx_reaction_target,priority:2"`
+	Emoji      string    `json:"emoji" gorm:"type:varchar(32);not null;uniqueIndex:idx_reaction_unique,priority:4"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ReactionCount 按目标和表情聚合的计数，随表态切换原子增减。
+type ReactionCount struct {
+	TargetType string `json:"target_type" gorm:"type:varchar(16);primaryKey"`
+	TargetId   uint   `json:"target_id" gorm:"primaryKey"`
+	Emoji      string `json:"emoji" gorm:"type:varchar(32);primaryKey"`
+	Count      int64  `json:"count" gorm:"not null;default:0"`
+}
@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// Series 表示作者创建的文章系列（如多篇连载教程）。
+type Series struct {
+	Id          uint      `json:"id" gorm:"primaryKey"`
+	UserId      uint      `json:"user_id" gorm:"index;not null"`
+	Title       string    `json:"title" gorm:"size:200;not null"`
+	Description string    `json:"description" gorm:"type:varchar(1000)"`
+	User        *User     `json:"user,omitempty" gorm:"foreignKey:UserId"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// SeriesPost 系列与文章的有序关联，一篇文章最多属于一个系列。
+type SeriesPost struct {
+	SeriesId  uint      `json:"series_id" gorm:"primaryKey"`
+	PostId    uint      `json:"post_id" gorm:"primaryKey;uniqueIndex"`
+	Position  int       `json:"position" gorm:"not null;index"`
+	Post      Post      `json:"-" gorm:"foreignKey:PostId"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SeriesPart 系列导航中的一篇文章。
+type SeriesPart struct {
+	PostId uint   `json:"post_id"`
+	Title  string `json:"title"`
+}
+
+// SeriesNav 文章详情中的系列导航（不落库）。
+type SeriesNav struct {
+	SeriesId uint        `json:"series_id"`
+	Title    string      `json:"title"`
+	Position int         `json:"position"` // 当前文章是第几篇，从 1 开始
+	Total    int         `json:"total"`
+	Prev     *SeriesPart `json:"prev,omitempty"`
+	Next     *SeriesPart `json:"next,omitempty"`
+}
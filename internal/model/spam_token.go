@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// SpamToken 贝叶斯垃圾内容分类器的词频：词在已判定的垃圾/正常样本中出现的次数。
+type SpamToken struct {
+	Token     string    `json:"token" gorm:"primaryKey;type:varchar(128)"`
+	Spam      int64     `json:"spam" gorm:"not null;default:0"`
+	Ham       int64     `json:"ham" gorm:"not null;default:0"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
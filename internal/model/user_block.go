@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// UserBlock 用户屏蔽关系：UserId 屏蔽了 BlockedId，被屏蔽者的 @提及不会生效。
+type UserBlock struct {
+	UserId    uint      `json:"user_id" gorm:"primaryKey"`
+	BlockedId uint      `json:"blocked_id" gorm:"primaryKey;index"`
+	Blocked   *User     `json:"blocked,omitempty" gorm:"foreignKey:BlockedId"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,29 @@
+// Package realtime 提供实时推送（SSE）使用的发布/订阅代理。
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// TypeResync 无法按 Last-Event-ID 续传（事件已过期、代理重启或 ID 无效）时发送给订阅者的事件，
+// 客户端收到后应重新拉取数据。
+const TypeResync = "resync"
+
+// Event 推送给订阅者的一条事件。ID 由代理分配，客户端断线重连时据此续传。
+type Event struct {
+	ID    string
+	Topic string
+	Type  string // SSE 事件名
+	Data  json.RawMessage
+}
+
+// Broker 发布/订阅代理。单实例部署使用 MemoryBroker；多实例部署时实现该接口接入共享代理
+// （如 Redis Streams），由共享代理分配事件 ID，使任一实例都能按 Last-Event-ID 续传。
+type Broker interface {
+	// Publish 向主题发布事件，返回分配的事件 ID。
+	Publish(ctx context.Context, topic, typ string, data json.RawMessage) (string, error)
+	// Subscribe 订阅主题；lastEventID 非空时先补发其后的事件，无法续传时先发送 TypeResync。
+	// ctx 结束或订阅者消费过慢被丢弃时 channel 关闭。
+	Subscribe(ctx context.Context, topic, lastEventID string) (<-chan Event, error)
+}
@@ -0,0 +1,129 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// subscriberBuffer 订阅者 channel 的缓冲大小，写满时视为消费过慢并断开（客户端重连后续传）。
+const subscriberBuffer = 64
+
+// MemoryBroker 进程内的发布/订阅代理。每个主题保留最近 History 条事件用于续传，
+// 没有订阅者且超过 TTL 未发布的主题会被清理。
+type MemoryBroker struct {
+	History int
+	TTL     time.Duration
+
+	mu        sync.Mutex
+	epoch     string // 实例标识，重启后旧的事件 ID 不会被误认为有效
+	seq       uint64
+	topics    map[string]*memoryTopic
+	lastSweep time.Time
+}
+
+type memoryTopic struct {
+	events    []Event
+	subs      map[chan Event]struct{}
+	updatedAt time.Time
+}
+
+// NewMemoryBroker 构造进程内代理。
+func NewMemoryBroker(history int, ttl time.Duration) *MemoryBroker {
+	return &MemoryBroker{
+		History: history,
+		TTL:     ttl,
+		epoch:   strconv.FormatInt(time.Now().UnixNano(), 36),
+		topics:  make(map[string]*memoryTopic),
+	}
+}
+
+// Publish 实现 Broker：事件 ID 形如 "<实例标识>-<序号>"，序号全局递增。
+func (b *MemoryBroker) Publish(_ context.Context, topic, typ string, data json.RawMessage) (string, error) {
+	now := time.Now()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	ev := Event{
+		ID:    b.epoch + "-" + strconv.FormatUint(b.seq, 10),
+		Topic: topic,
+		Type:  typ,
+		Data:  data,
+	}
+	t := b.topic(topic)
+	t.updatedAt = now
+	if b.History > 0 {
+		t.events = append(t.events, ev)
+		if n := len(t.events); n > b.History {
+			t.events = append(t.events[:0:0], t.events[n-b.History:]...)
+		}
+	}
+	for ch := range t.subs {
+		select {
+		case ch <- ev:
+		default:
+			delete(t.subs, ch)
+			close(ch)
+		}
+	}
+	if now.Sub(b.lastSweep) > b.TTL {
+		b.sweep(now)
+		b.lastSweep = now
+	}
+	return ev.ID, nil
+}
+
+// Subscribe 实现 Broker。
+func (b *MemoryBroker) Subscribe(ctx context.Context, topic, lastEventID string) (<-chan Event, error) {
+	b.mu.Lock()
+	t := b.topic(topic)
+	var replay []Event
+	if lastEventID != "" {
+		replay = []Event{{Topic: topic, Type: TypeResync, Data: json.RawMessage("{}")}}
+		for i, ev := range t.events {
+			if ev.ID == lastEventID {
+				replay = t.events[i+1:]
+				break
+			}
+		}
+	}
+	ch := make(chan Event, subscriberBuffer+len(replay))
+	for _, ev := range replay {
+		ch <- ev
+	}
+	t.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := t.subs[ch]; ok {
+			delete(t.subs, ch)
+			close(ch)
+		}
+	}()
+	return ch, nil
+}
+
+// topic 返回主题，不存在时创建（调用方持有锁）。
+func (b *MemoryBroker) topic(name string) *memoryTopic {
+	t, ok := b.topics[name]
+	if !ok {
+		t = &memoryTopic{subs: make(map[chan Event]struct{}), updatedAt: time.Now()}
+		b.topics[name] = t
+	}
+	return t
+}
+
+// sweep 清理没有订阅者且历史已过期的主题（调用方持有锁）。
+func (b *MemoryBroker) sweep(now time.Time) {
+	for name, t := range b.topics {
+		if len(t.subs) == 0 && now.Sub(t.updatedAt) > b.TTL {
+			delete(b.topics, name)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func publish(t *testing.T, b *MemoryBroker, topic, typ string) string {
+	t.Helper()
+	id, err := b.Publish(context.Background(), topic, typ, json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	return id
+}
+
+func subscribe(t *testing.T, b *MemoryBroker, topic, lastEventID string) <-chan Event {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	ch, err := b.Subscribe(ctx, topic, lastEventID)
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	return ch
+}
+
+// receive 读取下一条事件，超时或 channel 已关闭时失败。
+func receive(t *testing.T, ch <-chan Event) Event {
+	t.Helper()
+	select {
+	case ev, ok := <-ch:
+		if !ok {
+			t.Fatal("channel closed")
+		}
+		return ev
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+	return Event{}
+}
+
+func TestMemoryBrokerDelivers(t *testing.T) {
+	b := NewMemoryBroker(10, time.Hour)
+	ch := subscribe(t, b, "post:1", "")
+	other := subscribe(t, b, "post:2", "")
+
+	id := publish(t, b, "post:1", "created")
+	if ev := receive(t, ch); ev.ID != id || ev.Type != "created" || ev.Topic != "post:1" {
+		t.Fatalf("unexpected event %+v", ev)
+	}
+	select {
+	case ev := <-other:
+		t.Fatalf("other topic received %+v", ev)
+	default:
+	}
+}
+
+func TestMemoryBrokerReplay(t *testing.T) {
+	b := NewMemoryBroker(10, time.Hour)
+	first := publish(t, b, "post:1", "a")
+	second := publish(t, b, "post:1", "b")
+	third := publish(t, b, "post:1", "c")
+
+	ch := subscribe(t, b, "post:1", first)
+	for _, want := range []string{second, third} {
+		if ev := receive(t, ch); ev.ID != want {
+			t.Fatalf("replayed %s, want %s", ev.ID, want)
+		}
+	}
+	// 续传之后继续接收新事件
+	fourth := publish(t, b, "post:1", "d")
+	if ev := receive(t, ch); ev.ID != fourth {
+		t.Fatalf("got %s, want %s", ev.ID, fourth)
+	}
+
+	// 已是最新的 ID：不补发也不要求重新同步
+	latest := subscribe(t, b, "post:1", fourth)
+	select {
+	case ev := <-latest:
+		t.Fatalf("unexpected event %+v", ev)
+	default:
+	}
+}
+
+func TestMemoryBrokerResync(t *testing.T) {
+	b := NewMemoryBroker(2, time.Hour)
+	expired := publish(t, b, "post:1", "a")
+	publish(t, b, "post:1", "b")
+	publish(t, b, "post:1", "c")
+
+	tests := []struct {
+		name string
+		id   string
+	}{
+		{"unknown id", "bogus-1"},
+		{"other instance", NewMemoryBroker(2, time.Hour).epoch + "-2"},
+		{"expired from history", expired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := subscribe(t, b, "post:1", tt.id)
+			if ev := receive(t, ch); ev.Type != TypeResync {
+				t.Fatalf("first event %+v, want resync", ev)
+			}
+			select {
+			case ev := <-ch:
+				t.Fatalf("unexpected event after resync %+v", ev)
+			default:
+			}
+		})
+	}
+}
+
+func TestMemoryBrokerDropsSlowSubscriber(t *testing.T) {
+	b := NewMemoryBroker(0, time.Hour)
+	slow := subscribe(t, b, "post:1", "")
+	fast := subscribe(t, b, "post:1", "")
+
+	for i := 0; i < subscriberBuffer+1; i++ {
+		publish(t, b, "post:1", "x")
+		receive(t, fast)
+	}
+	n := 0
+	for range slow {
+		n++
+	}
+	if n != subscriberBuffer {
+		t.Fatalf("slow subscriber got %d events before close, want %d", n, subscriberBuffer)
+	}
+
+	// 其余订阅者不受影响
+	publish(t, b, "post:1", "y")
+	if ev := receive(t, fast); ev.Type != "y" {
+		t.Fatalf("fast subscriber got %+v", ev)
+	}
+}
+
+func TestMemoryBrokerUnsubscribe(t *testing.T) {
+	b := NewMemoryBroker(0, time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := b.Subscribe(ctx, "post:1", "")
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	cancel()
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatal("expected channel to be closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+}
+
+func TestMemoryBrokerSweep(t *testing.T) {
+	b := NewMemoryBroker(10, 20*time.Millisecond)
+	publish(t, b, "idle", "a")
+	subscribe(t, b, "watched", "")
+	publish(t, b, "watched", "a")
+
+	time.Sleep(40 * time.Millisecond)
+	publish(t, b, "fresh", "a")
+
+	b.mu.Lock()
+	_, idle := b.topics["idle"]
+	_, watched := b.topics["watched"]
+	_, fresh := b.topics["fresh"]
+	b.mu.Unlock()
+	if idle {
+		t.Fatal("idle topic past TTL should be swept")
+	}
+	if !watched {
+		t.Fatal("topic with subscribers must be kept")
+	}
+	if !fresh {
+		t.Fatal("just published topic must be kept")
+	}
+}
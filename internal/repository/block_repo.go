@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+
+	"go-blog/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BlockRepository 负责用户屏蔽关系的存取。
+type BlockRepository struct {
+	DB *gorm.DB
+}
+
+// NewBlockRepository 创建屏蔽仓库。
+func NewBlockRepository(db *gorm.DB) *BlockRepository {
+	return &BlockRepository{DB: db}
+}
+
+// Block 屏蔽用户，重复屏蔽不报错。
+func (r *BlockRepository) Block(ctx context.Context, uid, blockedID uint) error {
+	return r.DB.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.UserBlock{UserId: uid, BlockedId: blockedID}).Error
+}
+
+// Unblock 取消屏蔽。
+func (r *BlockRepository) Unblock(ctx context.Context, uid, blockedID uint) error {
+	return r.DB.WithContext(ctx).
+		Where("user_id = ? AND blocked_id = ?", uid, blockedID).
+		Delete(&model.UserBlock{}).Error
+}
+
+// ListBlocked 分页查询用户屏蔽的人（预加载被屏蔽用户，最近屏蔽的在前）。
+func (r *BlockRepository) ListBlocked(ctx context.Context, uid uint, page, pageSize int) ([]model.UserBlock, int64, error) {
+	db := r.DB.WithContext(ctx).Model(&model.UserBlock{}).Where("user_id = ?", uid)
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var blocks []model.UserBlock
+	if err := db.Preload("Blocked").
+		Order("created_at DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&blocks).Error; err != nil {
+		return nil, 0, err
+	}
+	return blocks, total, nil
+}
+
+// BlockersOf 返回 userIDs 中屏蔽了 authorID 的用户集合。
+func (r *BlockRepository) BlockersOf(ctx context.Context, userIDs []uint, authorID uint) (map[uint]bool, error) {
+	out := make(map[uint]bool)
+	if len(userIDs) == 0 {
+		return out, nil
+	}
+	var ids []uint
+	if err := r.DB.WithContext(ctx).
+		Model(&model.UserBlock{}).
+		Where("user_id IN ? AND blocked_id = ?", userIDs, authorID).
+		Pluck("user_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		out[id] = true
+	}
+	return out, nil
+}
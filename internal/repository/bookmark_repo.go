@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+
+	"go-blog/internal/model"
+	"gorm.io/gorm"
+)
+
+// BookmarkRepository 负责收藏与阅读清单的存取。
+type BookmarkRepository struct {
+	DB *gorm.DB
+}
+
+// NewBookmarkRepository 创建收藏仓库。
+func NewBookmarkRepository(db *gorm.DB) *BookmarkRepository {
+	return &BookmarkRepository{DB: db}
+}
+
+// FindByUserPost 查询用户对某篇文章的收藏。
+func (r *BookmarkRepository) FindByUserPost(ctx context.Context, uid, postID uint) (*model.Bookmark, error) {
+	var b model.Bookmark
+	if err := r.DB.WithContext(ctx).
+		Where("user_id = ? AND post_id = ?", uid, postID).
+		First(&b).Error; err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// Create 新增收藏。
+func (r *BookmarkRepository) Create(ctx context.Context, b *model.Bookmark) error {
+	return r.DB.WithContext(ctx).Create(b).Error
+}
+
+// Save 保存收藏（用于调整所属清单）。
+func (r *BookmarkRepository) Save(ctx context.Context, b *model.Bookmark) error {
+	return r.DB.WithContext(ctx).Save(b).Error
+}
+
+// DeleteByUserPost 取消收藏，返回删除的行数。
+func (r *BookmarkRepository) DeleteByUserPost(ctx context.Context, uid, postID uint) (int64, error) {
+	res := r.DB.WithContext(ctx).
+		Where("user_id = ? AND post_id = ?", uid, postID).
+		Delete(&model.Bookmark{})
+	return res.RowsAffected, res.Error
+}
+
+// BookmarkFilter 收藏列表筛选条件。
+type BookmarkFilter struct {
+	UserID        uint
+	ReadingListID *uint
+	ViewerID      uint // 只返回对该用户可见的文章，匿名访问传 0
+	PublishedOnly bool // 同时排除不公开文章，用于分享的公开清单
+	Page          int
+	PageSize      int
+}
+
+// List 按条件分页查询收藏并预加载文章及作者。
+func (r *BookmarkRepository) List(ctx context.Context, f BookmarkFilter) ([]model.Bookmark, int64, error) {
+	// 只列出未进入回收站且对查看者可见的文章
+	posts := &PostRepository{DB: r.DB}
+	db := r.DB.WithContext(ctx).Model(&model.Bookmark{}).
+		Joins("JOIN posts ON posts.id = bookmarks.post_id AND posts.deleted_at IS NULL").
+		Scopes(posts.visibleTo(f.ViewerID, f.PublishedOnly))
+
+	if f.UserID > 0 {
+		db = db.Where("bookmarks.user_id = ?", f.UserID)
+	}
+	if f.ReadingListID != nil {
+		db = db.Where("bookmarks.reading_list_id = ?", *f.ReadingListID)
+	}
+	if f.PublishedOnly {
+		db = db.Where("posts.status = ?", "published")
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	page := f.Page
+	if page <= 0 {
+		page = 1
+	}
+	pageSize := f.PageSize
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	var bookmarks []model.Bookmark
+	if err := db.Preload("Post").Preload("Post.User").Preload("Post.Authors").
+		Order("bookmarks.created_at DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&bookmarks).Error; err != nil {
+		return nil, 0, err
+	}
+	return bookmarks, total, nil
+}
+
+// ListAllByUser 查询用户的全部收藏（用于数据导出），只包含对该用户仍可见的文章。
+func (r *BookmarkRepository) ListAllByUser(ctx context.Context, uid uint) ([]model.Bookmark, error) {
+	var bookmarks []model.Bookmark
+	posts := &PostRepository{DB: r.DB}
+	if err := r.DB.WithContext(ctx).
+		Joins("JOIN posts ON posts.id = bookmarks.post_id").
+		Scopes(posts.visibleTo(uid, false)).
+		Where("bookmarks.user_id = ?", uid).
+		Preload("Post").Preload("Post.User").Preload("Post.Authors").
+		Order("bookmarks.created_at DESC").
+		Find(&bookmarks).Error; err != nil {
+		return nil, err
+	}
+	return bookmarks, nil
+}
+
+// CreateList 新增阅读清单。
+func (r *BookmarkRepository) CreateList(ctx context.Context, l *model.ReadingList) error {
+	return r.DB.WithContext(ctx).Create(l).Error
+}
+
+// SaveList 保存阅读清单。
+func (r *BookmarkRepository) SaveList(ctx context.Context, l *model.ReadingList) error {
+	return r.DB.WithContext(ctx).Save(l).Error
+}
+
+// FindListByID 按ID查询阅读清单。
+func (r *BookmarkRepository) FindListByID(ctx context.Context, id uint) (*model.ReadingList, error) {
+	var l model.ReadingList
+	if err := r.DB.WithContext(ctx).First(&l, id).Error; err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// FindListByToken 按分享令牌查询阅读清单。
+func (r *BookmarkRepository) FindListByToken(ctx context.Context, token string) (*model.ReadingList, error) {
+	var l model.ReadingList
+	if err := r.DB.WithContext(ctx).Where("share_token = ?", token).First(&l).Error; err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// ListListsByUser 查询用户的全部阅读清单。
+func (r *BookmarkRepository) ListListsByUser(ctx context.Context, uid uint) ([]model.ReadingList, error) {
+	var lists []model.ReadingList
+	if err := r.DB.WithContext(ctx).
+		Where("user_id = ?", uid).
+		Order("id ASC").
+		Find(&lists).Error; err != nil {
+		return nil, err
+	}
+	return lists, nil
+}
+
+// DeleteList 删除阅读清单，清单内的收藏保留但不再归属任何清单。
+func (r *BookmarkRepository) DeleteList(ctx context.Context, l *model.ReadingList) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Bookmark{}).
+			Where("reading_list_id = ?", l.Id).
+			Update("reading_list_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(l).Error
+	})
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"go-blog/internal/model"
 	"gorm.io/gorm"
@@ -32,3 +33,16 @@ func (r *CategoryRepository) ListOrdered(ctx context.Context) ([]model.Category,
 func (r *CategoryRepository) Create(ctx context.Context, category *model.Category) error {
 	return r.DB.WithContext(ctx).Create(category).Error
 }
+
+// FindByNameOrSlug 按名称或 slug 查找分类（名称优先）。
+func (r *CategoryRepository) FindByNameOrSlug(ctx context.Context, name, slug string) (*model.Category, error) {
+	var category model.Category
+	err := r.DB.WithContext(ctx).Where("name = ?", name).First(&category).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = r.DB.WithContext(ctx).Where("slug = ?", slug).First(&category).Error
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
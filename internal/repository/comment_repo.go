@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"time"
 
 	"go-blog/internal/model"
@@ -18,9 +20,86 @@ func NewCommentRepository(db *gorm.DB) *CommentRepository {
 	return &CommentRepository{DB: db}
 }
 
-// Create 新增评论。
+// WithDB 用于在事务中替换为 tx
+func (r *CommentRepository) WithDB(db *gorm.DB) *CommentRepository {
+	return &CommentRepository{DB: db}
+}
+
+// Create 新增评论，并根据父评论维护物化路径与层级。
 func (r *CommentRepository) Create(ctx context.Context, comment *model.Comment) error {
-	return r.DB.WithContext(ctx).Create(comment).Error
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		prefix := ""
+		comment.Depth = 0
+		if comment.ParentId != nil {
+			var parent model.Comment
+			if err := tx.Unscoped().Select("id", "path", "depth").First(&parent, *comment.ParentId).Error; err != nil {
+				return err
+			}
+			prefix = parent.Path
+			comment.Depth = parent.Depth + 1
+		}
+		if err := tx.Create(comment).Error; err != nil {
+			return err
+		}
+		comment.Path = prefix + model.CommentPathSegment(comment.Id)
+		return tx.Model(comment).UpdateColumn("path", comment.Path).Error
+	})
+}
+
+// Move 将评论（连同其子树）移动到新的父评论下，parentID 为空表示成为顶层评论。
+func (r *CommentRepository) Move(ctx context.Context, comment *model.Comment, parentID *uint) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return moveComment(tx, comment, parentID)
+	})
+}
+
+func moveComment(tx *gorm.DB, comment *model.Comment, parentID *uint) error {
+	prefix, depth := "", 0
+	if parentID != nil {
+		var parent model.Comment
+		if err := tx.Unscoped().Select("id", "path", "depth").First(&parent, *parentID).Error; err != nil {
+			return err
+		}
+		if comment.Path != "" && strings.HasPrefix(parent.Path, comment.Path) {
+			return errors.New("comment cannot be moved into its own subtree")
+		}
+		prefix, depth = parent.Path, parent.Depth+1
+	}
+	newPath := prefix + model.CommentPathSegment(comment.Id)
+	if err := rebaseSubtree(tx, comment.Path, newPath, depth-comment.Depth); err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Model(&model.Comment{}).
+		Where("id = ?", comment.Id).
+		UpdateColumn("parent_id", parentID).Error; err != nil {
+		return err
+	}
+	comment.ParentId, comment.Path, comment.Depth = parentID, newPath, depth
+	return nil
+}
+
+// rebaseSubtree 将路径前缀为 oldPath 的所有评论（含回收站中的）改为 newPath 前缀，层级加 delta。
+func rebaseSubtree(tx *gorm.DB, oldPath, newPath string, delta int) error {
+	if oldPath == "" || oldPath == newPath {
+		return nil
+	}
+	return tx.Exec(`UPDATE comments SET path = CONCAT(?, SUBSTRING(path, ?)), depth = depth + ?
+WHERE path LIKE ?`, newPath, len(oldPath)+1, delta, oldPath+"%").Error
+}
+
+// promoteChildren 将评论的直接回复及其子树上移一级，挂到该评论的父评论下（删除评论时保留他人的回复）。
+func promoteChildren(tx *gorm.DB, comment *model.Comment) error {
+	if comment.Path == "" {
+		return nil
+	}
+	if err := tx.Unscoped().Model(&model.Comment{}).
+		Where("parent_id = ?", comment.Id).
+		UpdateColumn("parent_id", comment.ParentId).Error; err != nil {
+		return err
+	}
+	parentPath := strings.TrimSuffix(comment.Path, model.CommentPathSegment(comment.Id))
+	return tx.Exec(`UPDATE comments SET path = CONCAT(?, SUBSTRING(path, ?)), depth = depth - 1
+WHERE path LIKE ? AND id <> ?`, parentPath, len(comment.Path)+1, comment.Path+"%", comment.Id).Error
 }
 
 // FindByID 按ID查询评论。
@@ -32,16 +111,198 @@ func (r *CommentRepository) FindByID(ctx context.Context, id uint) (*model.Comme
 	return &comment, nil
 }
 
-// Delete 删除评论。
-func (r *CommentRepository) Delete(ctx context.Context, comment *model.Comment) error {
-	return r.DB.WithContext(ctx).Delete(comment).Error
+// Save 保存评论（更新）。
+func (r *CommentRepository) Save(ctx context.Context, comment *model.Comment) error {
+	return r.DB.WithContext(ctx).Save(comment).Error
+}
+
+// errEditStale 编辑期间评论状态已被修改，用于回滚 Edit 的事务。
+var errEditStale = errors.New("comment status changed")
+
+// Edit 保存评论的新内容与状态，并将编辑前的版本写入历史（同一事务）；仅当状态仍为 prevStatus 时更新，
+// 否则（例如审核员同时作出了决定）不做任何修改并返回 false。
+func (r *CommentRepository) Edit(ctx context.Context, comment *model.Comment, prevStatus string, rev *model.CommentRevision) (bool, error) {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.Comment{}).
+			Where("id = ? AND status = ?", comment.Id, prevStatus).
+			Updates(map[string]interface{}{
+				"content":   comment.Content,
+				"status":    comment.Status,
+				"edited_at": comment.EditedAt,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errEditStale
+		}
+		return tx.Create(rev).Error
+	})
+	if errors.Is(err, errEditStale) {
+		return false, nil
+	}
+	return err == nil, err
 }
 
-// ListByPostID 查询文章下的所有评论并预加载用户。
+// ListRevisions 查询评论的历史版本（最新的在前）并预加载编辑人。
+func (r *CommentRepository) ListRevisions(ctx context.Context, commentID uint) ([]model.CommentRevision, error) {
+	var revs []model.CommentRevision
+	if err := r.DB.WithContext(ctx).
+		Where("comment_id = ?", commentID).
+		Preload("Editor").
+		Order("id DESC").
+		Find(&revs).Error; err != nil {
+		return nil, err
+	}
+	return revs, nil
+}
+
+// Delete 删除评论：仍有回复（含未公开的回复）时只标记为占位（tombstone），回复线程保持不变；
+// 否则软删除进入回收站，并永久删除因此不再有回复的占位祖先。返回是否为占位删除。
+func (r *CommentRepository) Delete(ctx context.Context, comment *model.Comment) (bool, error) {
+	tombstoned := false
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var replies int64
+		if err := tx.Model(&model.Comment{}).Where("parent_id = ?", comment.Id).Count(&replies).Error; err != nil {
+			return err
+		}
+		if replies > 0 {
+			tombstoned = true
+			if err := purgeMentions(tx, model.MentionTargetComment, []uint{comment.Id}); err != nil {
+				return err
+			}
+			return tx.Model(comment).UpdateColumn("tombstone", true).Error
+		}
+		if err := tx.Delete(comment).Error; err != nil {
+			return err
+		}
+		return purgeEmptyTombstones(tx, comment.ParentId)
+	})
+	if err == nil && tombstoned {
+		comment.Tombstone = true
+	}
+	return tombstoned, err
+}
+
+// purgeEmptyTombstones 自 parentID 起向上逐级永久删除已没有回复的占位评论。
+func purgeEmptyTombstones(tx *gorm.DB, parentID *uint) error {
+	for parentID != nil {
+		var parent model.Comment
+		err := tx.Unscoped().Select("id", "parent_id", "tombstone").First(&parent, *parentID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !parent.Tombstone {
+			return nil
+		}
+		var replies int64
+		if err := tx.Model(&model.Comment{}).Where("parent_id = ?", parent.Id).Count(&replies).Error; err != nil {
+			return err
+		}
+		if replies > 0 {
+			return nil
+		}
+		if err := purgeComment(tx, parent.Id); err != nil {
+			return err
+		}
+		parentID = parent.ParentId
+	}
+	return nil
+}
+
+// purgeComment 永久删除单条评论及其表态、历史版本、提及与通知。
+func purgeComment(tx *gorm.DB, id uint) error {
+	if err := purgeReactions(tx, model.ReactionTargetComment, []uint{id}); err != nil {
+		return err
+	}
+	if err := tx.Where("comment_id = ?", id).Delete(&model.CommentRevision{}).Error; err != nil {
+		return err
+	}
+	if err := purgeMentions(tx, model.MentionTargetComment, []uint{id}); err != nil {
+		return err
+	}
+	if err := purgeNotifications(tx, "target_type = ? AND target_id = ?", model.MentionTargetComment, id); err != nil {
+		return err
+	}
+	return tx.Unscoped().Delete(&model.Comment{}, id).Error
+}
+
+// visibleTo 限定为对 uid 可见的评论：已通过审核的，或 uid 本人发表的。
+func visibleTo(uid uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("(comments.status = ? OR comments.user_id = ?)", model.CommentStatusApproved, uid)
+	}
+}
+
+// ListSubtree 查询评论及其对 uid 可见的全部后代（按物化路径排序，即先序遍历）并预加载用户。
+func (r *CommentRepository) ListSubtree(ctx context.Context, comment *model.Comment, uid uint) ([]model.Comment, error) {
+	var comments []model.Comment
+	if err := r.DB.WithContext(ctx).
+		Where("post_id = ? AND path LIKE ?", comment.PostId, comment.Path+"%").
+		Scopes(visibleTo(uid)).
+		Preload("User").
+		Order("path ASC").
+		Find(&comments).Error; err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// ListByPostID 查询文章下所有已通过审核的评论并预加载用户。
 func (r *CommentRepository) ListByPostID(ctx context.Context, postID uint) ([]model.Comment, error) {
 	var comments []model.Comment
 	if err := r.DB.WithContext(ctx).
-		Where("post_id = ?", postID).
+		Where("post_id = ? AND status = ?", postID, model.CommentStatusApproved).
+		Preload("User").
+		Order("id ASC").
+		Find(&comments).Error; err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// ListRootsByPost 分页查询文章下对 uid 可见的顶层评论（按时间正序）并返回顶层评论总数。
+func (r *CommentRepository) ListRootsByPost(ctx context.Context, postID, uid uint, page, pageSize int) ([]model.Comment, int64, error) {
+	db := r.DB.WithContext(ctx).Model(&model.Comment{}).
+		Where("post_id = ? AND parent_id IS NULL", postID).
+		Scopes(visibleTo(uid))
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var comments []model.Comment
+	if err := db.Preload("User").
+		Order("id ASC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&comments).Error; err != nil {
+		return nil, 0, err
+	}
+	return comments, total, nil
+}
+
+// ListRepliesPreview 为每个父评论取对 uid 可见的最早 n 条直接回复（窗口函数，一次查询）。
+func (r *CommentRepository) ListRepliesPreview(ctx context.Context, parentIDs []uint, uid uint, n int) ([]model.Comment, error) {
+	if len(parentIDs) == 0 || n <= 0 {
+		return nil, nil
+	}
+	var ids []uint
+	if err := r.DB.WithContext(ctx).Raw(`SELECT id FROM (
+	SELECT id, ROW_NUMBER() OVER (PARTITION BY parent_id ORDER BY id) AS rn
+	FROM comments WHERE parent_id IN ? AND deleted_at IS NULL AND (status = ? OR user_id = ?)
+) t WHERE rn <= ?`, parentIDs, model.CommentStatusApproved, uid, n).Scan(&ids).Error; err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var comments []model.Comment
+	if err := r.DB.WithContext(ctx).
+		Where("id IN ?", ids).
 		Preload("User").
 		Order("id ASC").
 		Find(&comments).Error; err != nil {
@@ -50,6 +311,103 @@ func (r *CommentRepository) ListByPostID(ctx context.Context, postID uint) ([]mo
 	return comments, nil
 }
 
+// ListReplies 游标分页查询某评论对 uid 可见的直接回复：返回 id 大于 afterID 的最多 limit 条（按 id 正序）。
+func (r *CommentRepository) ListReplies(ctx context.Context, parentID, uid, afterID uint, limit int) ([]model.Comment, error) {
+	var comments []model.Comment
+	if err := r.DB.WithContext(ctx).
+		Where("parent_id = ? AND id > ?", parentID, afterID).
+		Scopes(visibleTo(uid)).
+		Preload("User").
+		Order("id ASC").
+		Limit(limit).
+		Find(&comments).Error; err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// CountReplies 统计各评论对 uid 可见的直接回复数。
+func (r *CommentRepository) CountReplies(ctx context.Context, parentIDs []uint, uid uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64, len(parentIDs))
+	if len(parentIDs) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		ParentId uint
+		Count    int64
+	}
+	if err := r.DB.WithContext(ctx).
+		Model(&model.Comment{}).
+		Select("parent_id, COUNT(*) AS count").
+		Where("parent_id IN ?", parentIDs).
+		Scopes(visibleTo(uid)).
+		Group("parent_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.ParentId] = row.Count
+	}
+	return counts, nil
+}
+
+// CountByPost 统计文章下对 uid 可见的评论总数（含回复，不含占位评论）。
+func (r *CommentRepository) CountByPost(ctx context.Context, postID, uid uint) (int64, error) {
+	var count int64
+	if err := r.DB.WithContext(ctx).
+		Model(&model.Comment{}).
+		Where("post_id = ? AND tombstone = ?", postID, false).
+		Scopes(visibleTo(uid)).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountApprovedByUser 统计用户已通过审核的评论数（用于判断是否首次评论）。
+func (r *CommentRepository) CountApprovedByUser(ctx context.Context, uid uint) (int64, error) {
+	var count int64
+	if err := r.DB.WithContext(ctx).
+		Model(&model.Comment{}).
+		Where("user_id = ? AND status = ?", uid, model.CommentStatusApproved).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// FindByIDs 按 ID 批量查询评论并预加载用户。
+func (r *CommentRepository) FindByIDs(ctx context.Context, ids []uint) ([]model.Comment, error) {
+	var comments []model.Comment
+	if len(ids) == 0 {
+		return comments, nil
+	}
+	if err := r.DB.WithContext(ctx).
+		Where("id IN ?", ids).
+		Preload("User").
+		Find(&comments).Error; err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// SetSpamLabel 记录评论已用于训练垃圾分类器的标签。
+func (r *CommentRepository) SetSpamLabel(ctx context.Context, id uint, label string) error {
+	return r.DB.WithContext(ctx).
+		Model(&model.Comment{}).
+		Where("id = ?", id).
+		UpdateColumn("spam_label", label).Error
+}
+
+// UpdateStatus 批量修改评论审核状态，返回实际变更的条数。
+func (r *CommentRepository) UpdateStatus(ctx context.Context, ids []uint, status string) (int64, error) {
+	res := r.DB.WithContext(ctx).
+		Model(&model.Comment{}).
+		Where("id IN ? AND status <> ?", ids, status).
+		Update("status", status)
+	return res.RowsAffected, res.Error
+}
+
 func (r *CommentRepository) CountAll(ctx context.Context) (int64, error) {
 	var count int64
 	if err := r.DB.WithContext(ctx).Model(&model.Comment{}).Count(&count).Error; err != nil {
@@ -74,6 +432,7 @@ type CommentFilter struct {
 	UserID   *uint
 	PostID   *uint
 	Keyword  string
+	Status   string
 	Page     int
 	PageSize int
 }
@@ -95,6 +454,10 @@ func (r *CommentRepository) List(ctx context.Context, f CommentFilter) ([]model.
 		db = db.Where("content LIKE ?", like)
 	}
 
+	if f.Status != "" {
+		db = db.Where("status = ?", f.Status)
+	}
+
 	var total int64
 	if err := db.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -118,3 +481,130 @@ func (r *CommentRepository) List(ctx context.Context, f CommentFilter) ([]model.
 	}
 	return comments, total, nil
 }
+
+// ListByUserID 查询某用户发表的全部评论（用于数据导出）。
+func (r *CommentRepository) ListByUserID(ctx context.Context, userID uint) ([]model.Comment, error) {
+	var comments []model.Comment
+	if err := r.DB.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("id ASC").
+		Find(&comments).Error; err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// ListTrashed 分页查询回收站中的评论，按删除时间倒序。
+func (r *CommentRepository) ListTrashed(ctx context.Context, f TrashFilter) ([]model.Comment, int64, error) {
+	db := r.DB.WithContext(ctx).Unscoped().Model(&model.Comment{}).Where("deleted_at IS NOT NULL")
+	if f.UserID != nil {
+		db = db.Where("user_id = ?", *f.UserID)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	page := f.Page
+	if page <= 0 {
+		page = 1
+	}
+	pageSize := f.PageSize
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	var comments []model.Comment
+	if err := db.Order("deleted_at DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&comments).Error; err != nil {
+		return nil, 0, err
+	}
+	return comments, total, nil
+}
+
+// FindTrashedByID 查询回收站中的评论。
+func (r *CommentRepository) FindTrashedByID(ctx context.Context, id uint) (*model.Comment, error) {
+	var comment model.Comment
+	if err := r.DB.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL").
+		First(&comment, id).Error; err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// Restore 从回收站恢复评论；原父评论已不存在（被删除或清理）时挂到最近的仍存在的祖先下，没有则成为顶层评论。
+func (r *CommentRepository) Restore(ctx context.Context, id uint) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var comment model.Comment
+		if err := tx.Unscoped().First(&comment, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&comment).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		if comment.ParentId == nil {
+			return nil
+		}
+
+		ancestors := comment.PathIDs()
+		if n := len(ancestors); n > 0 && ancestors[n-1] == comment.Id {
+			ancestors = ancestors[:n-1]
+		}
+		if len(ancestors) == 0 || ancestors[len(ancestors)-1] != *comment.ParentId {
+			ancestors = append(ancestors, *comment.ParentId)
+		}
+		var alive []uint
+		if err := tx.Model(&model.Comment{}).Where("id IN ?", ancestors).Pluck("id", &alive).Error; err != nil {
+			return err
+		}
+		exists := make(map[uint]bool, len(alive))
+		for _, a := range alive {
+			exists[a] = true
+		}
+		if exists[*comment.ParentId] {
+			return nil
+		}
+		var target *uint
+		for i := len(ancestors) - 1; i >= 0; i-- {
+			if exists[ancestors[i]] {
+				target = &ancestors[i]
+				break
+			}
+		}
+		return moveComment(tx, &comment, target)
+	})
+}
+
+// ListTrashedIDsBefore 查询删除时间早于 cutoff 的评论ID。
+func (r *CommentRepository) ListTrashedIDsBefore(ctx context.Context, cutoff time.Time) ([]uint, error) {
+	var ids []uint
+	if err := r.DB.WithContext(ctx).Unscoped().
+		Model(&model.Comment{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Purge 永久删除评论及其表态数据与历史版本，并清理因此不再有回复的占位祖先。
+func (r *CommentRepository) Purge(ctx context.Context, id uint) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var comment model.Comment
+		if err := tx.Unscoped().First(&comment, id).Error; err != nil {
+			return err
+		}
+		// 有回复的评论删除时会成为占位而不进回收站；此处兜底处理历史数据中仍挂在其下的回复
+		if err := promoteChildren(tx, &comment); err != nil {
+			return err
+		}
+		if err := purgeComment(tx, id); err != nil {
+			return err
+		}
+		return purgeEmptyTombstones(tx, comment.ParentId)
+	})
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"go-blog/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ImportRepository 维护外部导入数据与本地记录的对应关系。
+type ImportRepository struct {
+	DB *gorm.DB
+}
+
+// NewImportRepository 创建导入记录仓库。
+func NewImportRepository(db *gorm.DB) *ImportRepository {
+	return &ImportRepository{DB: db}
+}
+
+// WithDB 用于在事务中替换为 tx
+func (r *ImportRepository) WithDB(db *gorm.DB) *ImportRepository {
+	return &ImportRepository{DB: db}
+}
+
+// LocalID 查询外部 ID 对应的本地 ID，未导入过时返回 0。
+func (r *ImportRepository) LocalID(ctx context.Context, source, kind, externalID string) (uint, error) {
+	var rec model.ImportRecord
+	err := r.DB.WithContext(ctx).
+		Where("source = ? AND kind = ? AND external_id = ?", source, kind, externalID).
+		Limit(1).
+		Find(&rec).Error
+	return rec.LocalId, err
+}
+
+// Save 写入或更新对应关系。
+func (r *ImportRepository) Save(ctx context.Context, source, kind, externalID string, localID uint) error {
+	return r.DB.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "source"}, {Name: "kind"}, {Name: "external_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"local_id", "updated_at"}),
+		}).
+		Create(&model.ImportRecord{Source: source, Kind: kind, ExternalId: externalID, LocalId: localID}).Error
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go-blog/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MentionRepository 负责 @提及记录的存取。
+type MentionRepository struct {
+	DB *gorm.DB
+}
+
+// NewMentionRepository 创建提及仓库。
+func NewMentionRepository(db *gorm.DB) *MentionRepository {
+	return &MentionRepository{DB: db}
+}
+
+// Replace 将来源的提及整体替换为 mentions：删除不再出现的用户，新增的插入，
+// 仍然存在的保留原记录（含通知状态），编辑内容不会重复通知。
+func (r *MentionRepository) Replace(ctx context.Context, targetType string, targetID uint, mentions []model.Mention) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		userIDs := make([]uint, 0, len(mentions))
+		for _, m := range mentions {
+			userIDs = append(userIDs, m.UserId)
+		}
+		del := tx.Where("target_type = ? AND target_id = ?", targetType, targetID)
+		if len(userIDs) > 0 {
+			del = del.Where("user_id NOT IN ?", userIDs)
+		}
+		if err := del.Delete(&model.Mention{}).Error; err != nil {
+			return err
+		}
+		if len(mentions) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&mentions).Error
+	})
+}
+
+// ListByTargets 批量查询来源的提及：来源ID -> 提及列表（按记录顺序）。
+func (r *MentionRepository) ListByTargets(ctx context.Context, targetType string, ids []uint) (map[uint][]model.Mention, error) {
+	out := make(map[uint][]model.Mention, len(ids))
+	if len(ids) == 0 {
+		return out, nil
+	}
+	var rows []model.Mention
+	if err := r.DB.WithContext(ctx).
+		Where("target_type = ? AND target_id IN ?", targetType, ids).
+		Order("id ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		out[row.TargetId] = append(out[row.TargetId], row)
+	}
+	return out, nil
+}
+
+// ClaimPending 取出来源中尚未通知的提及并标记为已通知；并发调用时每条提及只会被取出一次。
+func (r *MentionRepository) ClaimPending(ctx context.Context, targetType string, ids []uint) ([]model.Mention, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var rows []model.Mention
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("target_type = ? AND target_id IN ? AND notified_at IS NULL", targetType, ids).
+			Order("id ASC").
+			Find(&rows).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		claimed := make([]uint, 0, len(rows))
+		for _, row := range rows {
+			claimed = append(claimed, row.Id)
+		}
+		now := time.Now()
+		for i := range rows {
+			rows[i].NotifiedAt = &now
+		}
+		return tx.Model(&model.Mention{}).Where("id IN ?", claimed).UpdateColumn("notified_at", now).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// purgeMentions 删除来源上的提及记录。
+func purgeMentions(tx *gorm.DB, targetType string, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return tx.Where("target_type = ? AND target_id IN ?", targetType, ids).Delete(&model.Mention{}).Error
+}
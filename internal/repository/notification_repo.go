@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-blog/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationRepository 负责站内通知与通知偏好的存取。
+type NotificationRepository struct {
+	DB *gorm.DB
+}
+
+// NewNotificationRepository 创建通知仓库。
+func NewNotificationRepository(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{DB: db}
+}
+
+// Record 记录一次事件：接收人存在相同合并键的未读通知时合并进去（计数加一、刷新触发人与时间），
+// 否则新建一条。n 在返回时为写入后的通知。
+func (r *NotificationRepository) Record(ctx context.Context, n *model.Notification) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing model.Notification
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND group_key = ? AND read_at IS NULL", n.UserId, n.GroupKey).
+			Order("id DESC").
+			Limit(1).
+			Find(&existing)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			n.Count = 1
+			if n.ActorId > 0 {
+				n.ActorCount = 1
+			}
+			if err := tx.Create(n).Error; err != nil {
+				return err
+			}
+			if n.ActorId == 0 {
+				return nil
+			}
+			return tx.Create(&model.NotificationActor{NotificationId: n.Id, UserId: n.ActorId}).Error
+		}
+
+		newActor := int64(0)
+		if n.ActorId > 0 {
+			ins := tx.Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&model.NotificationActor{NotificationId: existing.Id, UserId: n.ActorId})
+			if ins.Error != nil {
+				return ins.Error
+			}
+			newActor = ins.RowsAffected
+		}
+		now := time.Now()
+		if err := tx.Model(&existing).UpdateColumns(map[string]interface{}{
+			"count":       gorm.Expr("count + 1"),
+			"actor_count": gorm.Expr("actor_count + ?", newActor),
+			"actor_id":    n.ActorId,
+			"target_type": n.TargetType,
+			"target_id":   n.TargetId,
+			"detail":      n.Detail,
+			"updated_at":  now,
+		}).Error; err != nil {
+			return err
+		}
+		n.Id, n.CreatedAt, n.UpdatedAt = existing.Id, existing.CreatedAt, now
+		n.Count, n.ActorCount = existing.Count+1, existing.ActorCount+newActor
+		return nil
+	})
+}
+
+// List 分页查询用户的通知（最近更新的在前），预加载最近触发人；unreadOnly 时只返回未读。
+func (r *NotificationRepository) List(ctx context.Context, uid uint, unreadOnly bool, page, pageSize int) ([]model.Notification, int64, error) {
+	db := r.DB.WithContext(ctx).Model(&model.Notification{}).Where("user_id = ?", uid)
+	if unreadOnly {
+		db = db.Where("read_at IS NULL")
+	}
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var list []model.Notification
+	if err := db.Preload("Actor").
+		Order("updated_at DESC, id DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+// FindByID 查询用户自己的一条通知并预加载触发人。
+func (r *NotificationRepository) FindByID(ctx context.Context, uid, id uint) (*model.Notification, error) {
+	var n model.Notification
+	if err := r.DB.WithContext(ctx).Preload("Actor").
+		Where("id = ? AND user_id = ?", id, uid).
+		First(&n).Error; err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// UnreadCounts 按类型统计用户的未读通知数。
+func (r *NotificationRepository) UnreadCounts(ctx context.Context, uid uint) (map[string]int64, error) {
+	var rows []struct {
+		Type  string
+		Count int64
+	}
+	if err := r.DB.WithContext(ctx).
+		Model(&model.Notification{}).
+		Select("type, COUNT(*) AS count").
+		Where("user_id = ? AND read_at IS NULL", uid).
+		Group("type").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		out[row.Type] = row.Count
+	}
+	return out, nil
+}
+
+// MarkRead 将用户的一条通知标记为已读，通知不存在时返回 gorm.ErrRecordNotFound。
+func (r *NotificationRepository) MarkRead(ctx context.Context, uid, id uint) error {
+	n, err := r.FindByID(ctx, uid, id)
+	if err != nil {
+		return err
+	}
+	if n.ReadAt != nil {
+		return nil
+	}
+	return r.DB.WithContext(ctx).Model(&model.Notification{}).
+		Where("id = ? AND read_at IS NULL", id).
+		UpdateColumn("read_at", time.Now()).Error
+}
+
+// MarkAllRead 将用户的全部未读通知标记为已读，返回标记的条数。
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, uid uint) (int64, error) {
+	res := r.DB.WithContext(ctx).Model(&model.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", uid).
+		UpdateColumn("read_at", time.Now())
+	return res.RowsAffected, res.Error
+}
+
+// Preferences 查询用户已设置的通知开关：类型 -> 是否开启。
+func (r *NotificationRepository) Preferences(ctx context.Context, uid uint) (map[string]bool, error) {
+	var rows []model.NotificationPreference
+	if err := r.DB.WithContext(ctx).Where("user_id = ?", uid).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		out[row.Type] = row.Enabled
+	}
+	return out, nil
+}
+
+// Enabled 判断用户是否开启了某类通知（未设置视为开启）。
+func (r *NotificationRepository) Enabled(ctx context.Context, uid uint, typ string) (bool, error) {
+	var pref model.NotificationPreference
+	err := r.DB.WithContext(ctx).Where("user_id = ? AND type = ?", uid, typ).First(&pref).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return pref.Enabled, nil
+}
+
+// SetPreferences 写入用户的通知开关（逐项覆盖，未提及的类型保持不变）。
+func (r *NotificationRepository) SetPreferences(ctx context.Context, uid uint, prefs map[string]bool) error {
+	if len(prefs) == 0 {
+		return nil
+	}
+	rows := make([]model.NotificationPreference, 0, len(prefs))
+	for typ, enabled := range prefs {
+		rows = append(rows, model.NotificationPreference{UserId: uid, Type: typ, Enabled: enabled})
+	}
+	return r.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"enabled"}),
+	}).Create(&rows).Error
+}
+
+// purgeNotifications 删除满足条件的通知及其触发人记录（永久删除文章或评论时在同一事务中调用）。
+func purgeNotifications(tx *gorm.DB, query string, args ...interface{}) error {
+	ids := tx.Model(&model.Notification{}).Select("id").Where(query, args...)
+	if err := tx.Where("notification_id IN (?)", ids).Delete(&model.NotificationActor{}).Error; err != nil {
+		return err
+	}
+	return tx.Where(query, args...).Delete(&model.Notification{}).Error
+}
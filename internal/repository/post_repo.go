@@ -6,15 +6,19 @@ import (
 
 	"go-blog/internal/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // PostFilter 文章列表筛选条件。
 type PostFilter struct {
-	CategoryID *uint   //分类 id
-	TagIDs     []uint  //标签 id 列表
-	Status     *string //状态
-	Keyword    string  //关键词：title/content 模糊查询
-	Order      string  //latest / hot
+	CategoryID *uint      //分类 id
+	TagIDs     []uint     //标签 id 列表
+	Status     *string    //状态
+	Keyword    string     //关键词：title/content 模糊查询
+	Order      string     //latest / hot
+	ViewerID   *uint      //非空时只返回该用户可见的文章；为空不限制（管理端）
+	From       *time.Time //创建时间下界（含）
+	To         *time.Time //创建时间上界（不含）
 	Page       int
 	PageSize   int
 }
@@ -36,7 +40,7 @@ func (r *PostRepository) WithDB(db *gorm.DB) *PostRepository {
 
 // ListPosts 根据过滤条件分页查询文章。
 func (r *PostRepository) ListPosts(ctx context.Context, f PostFilter) (posts []model.Post, total int64, err error) {
-	db := r.DB.WithContext(ctx).Model(&model.Post{}).Preload("Category").Preload("Tags").Preload("User")
+	db := r.DB.WithContext(ctx).Model(&model.Post{}).Preload("Category").Preload("Tags").Preload("User").Preload("Authors.User")
 	if f.CategoryID != nil && *f.CategoryID > 0 {
 		db = db.Where("category_id = ?", *f.CategoryID)
 	}
@@ -45,9 +49,26 @@ func (r *PostRepository) ListPosts(ctx context.Context, f PostFilter) (posts []m
 		db = db.Where("status = ?", *f.Status)
 	}
 
+	if f.ViewerID != nil {
+		db = db.Scopes(r.visibleTo(*f.ViewerID, true))
+	}
+
+	if f.From != nil {
+		db = db.Where("posts.created_at >= ?", *f.From)
+	}
+	if f.To != nil {
+		db = db.Where("posts.created_at < ?", *f.To)
+	}
+
 	if f.Keyword != "" {
 		kw := "%" + f.Keyword + "%"
-		db = db.Where("title like ? or content like ?", kw, kw)
+		if f.ViewerID != nil {
+			// 非作者不能通过正文检索到受保护文章
+			db = db.Where("posts.title like ? or (posts.content like ? and (posts.visibility <> ? or posts.id IN (?)))",
+				kw, kw, model.PostVisibilityPassword, r.authoredBy(*f.ViewerID))
+		} else {
+			db = db.Where("title like ? or content like ?", kw, kw)
+		}
 	}
 
 	if len(f.TagIDs) > 0 {
@@ -60,7 +81,7 @@ func (r *PostRepository) ListPosts(ctx context.Context, f PostFilter) (posts []m
 	case "latest":
 		db = db.Order("posts.created_at DESC")
 	case "hot":
-		db = db.Order("posts.id DESC")
+		db = db.Order("posts.hot_score DESC, posts.id DESC")
 	default:
 		db = db.Order("posts.id DESC")
 	}
@@ -82,12 +103,36 @@ func (r *PostRepository) ListPosts(ctx context.Context, f PostFilter) (posts []m
 	err = db.Offset(offset).Limit(f.PageSize).Find(&posts).Error
 	return
 }
-// ListByUserID 查询某用户的文章列表。
+
+// authoredBy 返回 uid 作为所有者或合著者的文章ID子查询。
+func (r *PostRepository) authoredBy(uid uint) *gorm.DB {
+	return r.DB.Model(&model.PostAuthor{}).Select("post_id").Where("user_id = ?", uid)
+}
+
+// visibleTo 限定为 viewerID 可见的文章：作者可见全部；私密文章仅对指定读者可见；
+// listed 为 true 时（列表、搜索）不公开文章只对作者列出。
+func (r *PostRepository) visibleTo(viewerID uint, listed bool) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		open := []string{model.PostVisibilityPublic, model.PostVisibilityPassword}
+		if !listed {
+			open = append(open, model.PostVisibilityUnlisted)
+		}
+		viewing := r.DB.Model(&model.PostViewer{}).Select("post_id").Where("user_id = ?", viewerID)
+		return db.Where(
+			"posts.visibility IN ? OR posts.user_id = ? OR posts.id IN (?) OR (posts.visibility = ? AND posts.id IN (?))",
+			open, viewerID, r.authoredBy(viewerID), model.PostVisibilityPrivate, viewing,
+		)
+	}
+}
+
+// ListByUserID 查询某用户的文章列表（包含其作为合著者参与的文章）。
 func (r *PostRepository) ListByUserID(ctx context.Context, userID uint) ([]model.Post, error) {
 	var posts []model.Post
-	if err := r.DB.Where("user_id = ?", userID).
+	if err := r.DB.WithContext(ctx).Model(&model.Post{}).
+		Where("posts.user_id = ? OR posts.id IN (?)", userID, r.authoredBy(userID)).
 		Preload("Category").
 		Preload("Tags").
+		Preload("Authors.User").
 		Order("created_at DESC").
 		Find(&posts).Error; err != nil {
 		return nil, err
@@ -95,6 +140,21 @@ func (r *PostRepository) ListByUserID(ctx context.Context, userID uint) ([]model
 	return posts, nil
 }
 
+// ListPublic 查询所有已发布且公开的文章（静态导出用），预加载作者、分类与标签，按时间倒序
+func (r *PostRepository) ListPublic(ctx context.Context) ([]model.Post, error) {
+	var posts []model.Post
+	if err := r.DB.WithContext(ctx).
+		Where("status = ? AND visibility = ?", model.PostStatusPublished, model.PostVisibilityPublic).
+		Preload("User").
+		Preload("Category").
+		Preload("Tags").
+		Order("created_at DESC, id DESC").
+		Find(&posts).Error; err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
 // Create 创建文章
 func (r *PostRepository) Create(ctx context.Context, post *model.Post) error {
 	return r.DB.WithContext(ctx).Create(post).Error
@@ -109,12 +169,72 @@ func (r *PostRepository) FindByID(ctx context.Context, id uint) (*model.Post, er
 	return &post, nil
 }
 
+// FindBySlug 根据 slug 查询文章（不含回收站中的文章）
+func (r *PostRepository) FindBySlug(ctx context.Context, slug string) (*model.Post, error) {
+	var post model.Post
+	if err := r.DB.WithContext(ctx).
+		Where("slug = ?", slug).
+		Order("id ASC").
+		First(&post).Error; err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// FindDetailByID 根据 ID 查询文章详情，预加载作者、分类、标签与全部作者
+func (r *PostRepository) FindDetailByID(ctx context.Context, id uint) (*model.Post, error) {
+	var post model.Post
+	if err := r.DB.WithContext(ctx).
+		Preload("User").
+		Preload("Category").
+		Preload("Tags").
+		Preload("Authors.User").
+		First(&post, id).Error; err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
 // Save 保存文章（更新）
 func (r *PostRepository) Save(ctx context.Context, post *model.Post) error {
 	return r.DB.WithContext(ctx).Save(post).Error
 }
 
-// Delete 删除文章
+// UpdateIfVersion 仅当版本号仍为 version 时更新文章可编辑字段并递增版本；
+// 版本不一致时返回 false 以及数据库中的最新版本号。
+func (r *PostRepository) UpdateIfVersion(ctx context.Context, post *model.Post, version uint64) (bool, uint64, error) {
+	res := r.DB.WithContext(ctx).
+		Model(&model.Post{}).
+		Where("id = ? AND version = ?", post.ID, version).
+		Updates(map[string]interface{}{
+			"title":              post.Title,
+			"content":            post.Content,
+			"status":             post.Status,
+			"category_id":        post.CategoryId,
+			"visibility":         post.Visibility,
+			"password_hash":      post.PasswordHash,
+			"comment_moderation": post.CommentModeration,
+			"version":            gorm.Expr("version + 1"),
+		})
+	if res.Error != nil {
+		return false, 0, res.Error
+	}
+	if res.RowsAffected == 0 {
+		// 加锁读取以拿到最新提交的版本，而不是事务快照
+		var current model.Post
+		if err := r.DB.WithContext(ctx).
+			Clauses(clause.Locking{Strength: "SHARE"}).
+			Select("id", "version").
+			First(&current, post.ID).Error; err != nil {
+			return false, 0, err
+		}
+		return false, current.Version, nil
+	}
+	post.Version = version + 1
+	return true, post.Version, nil
+}
+
+// Delete 删除文章（软删除，进入回收站）
 func (r *PostRepository) Delete(ctx context.Context, post *model.Post) error {
 	return r.DB.WithContext(ctx).Delete(post).Error
 }
@@ -133,11 +253,63 @@ func (r *PostRepository) ReplaceTags(ctx context.Context, post *model.Post, tagI
 	return r.DB.Model(post).Association("Tags").Replace(&tags)
 }
 
-// FindAllWithUser 查询所有文章并预加载作者
-func (r *PostRepository) FindAllWithUser(ctx context.Context) ([]model.Post, error) {
+// ListRelatedCandidates 查询相关文章候选：与给定标签或分类有交集、已发布且公开列出的文章，按时间倒序最多 limit 篇。
+func (r *PostRepository) ListRelatedCandidates(ctx context.Context, excludeID uint, tagIDs, categoryIDs []uint, limit int) ([]model.Post, error) {
+	db := r.DB.WithContext(ctx).Model(&model.Post{}).
+		Where("posts.id <> ? AND posts.status = ?", excludeID, model.PostStatusPublished).
+		Where("posts.visibility IN ?", []string{model.PostVisibilityPublic, model.PostVisibilityPassword})
+	if len(tagIDs) > 0 {
+		tagged := r.DB.Model(&model.PostTag{}).Select("post_id").Where("tag_id IN ?", tagIDs)
+		db = db.Where("posts.category_id IN ? OR posts.id IN (?)", categoryIDs, tagged)
+	} else {
+		db = db.Where("posts.category_id IN ?", categoryIDs)
+	}
+
+	var posts []model.Post
+	if err := db.Preload("User").Preload("Tags").
+		Order("posts.created_at DESC").
+		Limit(limit).
+		Find(&posts).Error; err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// ArchiveStat 按年月聚合的文章数。
+type ArchiveStat struct {
+	Year  int   `json:"year"`
+	Month int   `json:"month"`
+	Count int64 `json:"count"`
+}
+
+// ArchiveCounts 按年月统计 viewerID 可见的已发布文章数（倒序），可按分类与标签筛选。
+func (r *PostRepository) ArchiveCounts(ctx context.Context, viewerID uint, categoryID *uint, tagIDs []uint) ([]ArchiveStat, error) {
+	db := r.DB.WithContext(ctx).Model(&model.Post{}).
+		Where("posts.status = ?", model.PostStatusPublished).
+		Scopes(r.visibleTo(viewerID, true))
+	if categoryID != nil && *categoryID > 0 {
+		db = db.Where("posts.category_id = ?", *categoryID)
+	}
+	if len(tagIDs) > 0 {
+		tagged := r.DB.Model(&model.PostTag{}).Select("post_id").Where("tag_id IN ?", tagIDs)
+		db = db.Where("posts.id IN (?)", tagged)
+	}
+
+	var stats []ArchiveStat
+	err := db.Select("YEAR(posts.created_at) AS year, MONTH(posts.created_at) AS month, COUNT(*) AS count").
+		Group("YEAR(posts.created_at), MONTH(posts.created_at)").
+		Order("year DESC, month DESC").
+		Scan(&stats).Error
+	return stats, err
+}
+
+// FindAllWithUser 查询 viewerID 可见的全部文章并预加载作者
+func (r *PostRepository) FindAllWithUser(ctx context.Context, viewerID uint) ([]model.Post, error) {
 	var posts []model.Post
 	if err := r.DB.WithContext(ctx).
+		Scopes(r.visibleTo(viewerID, true)).
 		Preload("User").
+		Preload("Authors.User").
 		Find(&posts).Error; err != nil {
 		return nil, err
 	}
@@ -181,8 +353,8 @@ func (r *PostRepository) TopPostsByComments(ctx context.Context, limit int) ([]T
 	err := r.DB.WithContext(ctx).
 		Table("posts").
 		Select("posts.id as post_id, posts.title as title, COUNT(comments.id) as comment_count").
-		Joins("LEFT JOIN comments ON comments.post_id = posts.id").
-		Where("posts.status = ?", "published").
+		Joins("LEFT JOIN comments ON comments.post_id = posts.id AND comments.deleted_at IS NULL AND comments.status = ?", model.CommentStatusApproved).
+		Where("posts.status = ? AND posts.deleted_at IS NULL", "published").
 		Group("posts.id").
 		Order("comment_count DESC, posts.id DESC").
 		Limit(limit).
@@ -192,3 +364,325 @@ func (r *PostRepository) TopPostsByComments(ctx context.Context, limit int) ([]T
 	}
 	return stats, nil
 }
+
+// IncrementViews 批量累加文章浏览量，counts 为 文章ID -> 增量。
+func (r *PostRepository) IncrementViews(ctx context.Context, counts map[uint]int64) error {
+	if len(counts) == 0 {
+		return nil
+	}
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for id, n := range counts {
+			if n <= 0 {
+				continue
+			}
+			if err := tx.Model(&model.Post{}).
+				Where("id = ?", id).
+				UpdateColumn("view_count", gorm.Expr("view_count + ?", n)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SumViews 统计全部文章的累计浏览量。
+func (r *PostRepository) SumViews(ctx context.Context) (int64, error) {
+	var total int64
+	if err := r.DB.WithContext(ctx).
+		Model(&model.Post{}).
+		Select("COALESCE(SUM(view_count), 0)").
+		Scan(&total).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// TopViewedStat 表示浏览量最高的文章统计。
+type TopViewedStat struct {
+	PostID    uint
+	Title     string
+	ViewCount int64
+}
+
+// TopPostsByViews 按浏览量降序获取已发布文章。
+func (r *PostRepository) TopPostsByViews(ctx context.Context, limit int) ([]TopViewedStat, error) {
+	if limit <= 0 {
+		limit = 5
+	}
+	var stats []TopViewedStat
+	err := r.DB.WithContext(ctx).
+		Table("posts").
+		Select("posts.id as post_id, posts.title as title, posts.view_count as view_count").
+		Where("posts.status = ? AND posts.deleted_at IS NULL", "published").
+		Order("view_count DESC, posts.id DESC").
+		Limit(limit).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// HotScoreInput 热度计算所需的文章互动数据。
+type HotScoreInput struct {
+	PostID       uint
+	CreatedAt    time.Time
+	ViewCount    int64
+	LikeCount    int64
+	CommentCount int64
+}
+
+// ListHotScoreInputs 查询所有已发布文章的浏览、点赞与评论数。
+func (r *PostRepository) ListHotScoreInputs(ctx context.Context) ([]HotScoreInput, error) {
+	var inputs []HotScoreInput
+	err := r.DB.WithContext(ctx).
+		Table("posts").
+		Select("posts.id as post_id, posts.created_at as created_at, posts.view_count as view_count, "+
+			"posts.like_count as like_count, COUNT(comments.id) as comment_count").
+		Joins("LEFT JOIN comments ON comments.post_id = posts.id AND comments.deleted_at IS NULL AND comments.status = ?", model.CommentStatusApproved).
+		Where("posts.status = ? AND posts.deleted_at IS NULL", "published").
+		Group("posts.id").
+		Scan(&inputs).Error
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+// UpdateHotScores 批量写回热度分，scores 为 文章ID -> 分值。
+func (r *PostRepository) UpdateHotScores(ctx context.Context, scores map[uint]float64) error {
+	if len(scores) == 0 {
+		return nil
+	}
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for id, score := range scores {
+			if err := tx.Model(&model.Post{}).
+				Where("id = ?", id).
+				UpdateColumn("hot_score", score).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindByIDs 批量按 ID 查询文章（不预加载）。
+func (r *PostRepository) FindByIDs(ctx context.Context, ids []uint) ([]model.Post, error) {
+	var posts []model.Post
+	if len(ids) == 0 {
+		return posts, nil
+	}
+	if err := r.DB.WithContext(ctx).Where("id IN ?", ids).Find(&posts).Error; err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// ReplaceViewers 整体替换私密文章的指定读者。
+func (r *PostRepository) ReplaceViewers(ctx context.Context, postID uint, userIDs []uint) error {
+	db := r.DB.WithContext(ctx)
+	if err := db.Where("post_id = ?", postID).Delete(&model.PostViewer{}).Error; err != nil {
+		return err
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+	viewers := make([]model.PostViewer, 0, len(userIDs))
+	for _, uid := range userIDs {
+		viewers = append(viewers, model.PostViewer{PostId: postID, UserId: uid})
+	}
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&viewers).Error
+}
+
+// IsViewer 判断用户是否为文章的指定读者。
+func (r *PostRepository) IsViewer(ctx context.Context, postID, uid uint) (bool, error) {
+	var count int64
+	if err := r.DB.WithContext(ctx).
+		Model(&model.PostViewer{}).
+		Where("post_id = ? AND user_id = ?", postID, uid).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// FindAuthor 查询用户在文章中的作者关系。
+func (r *PostRepository) FindAuthor(ctx context.Context, postID, userID uint) (*model.PostAuthor, error) {
+	var a model.PostAuthor
+	if err := r.DB.WithContext(ctx).
+		Where("post_id = ? AND user_id = ?", postID, userID).
+		First(&a).Error; err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// AddAuthor 新增文章作者关系。
+func (r *PostRepository) AddAuthor(ctx context.Context, a *model.PostAuthor) error {
+	return r.DB.WithContext(ctx).Create(a).Error
+}
+
+// RemoveAuthor 删除文章作者关系。
+func (r *PostRepository) RemoveAuthor(ctx context.Context, postID, userID uint) error {
+	return r.DB.WithContext(ctx).
+		Where("post_id = ? AND user_id = ?", postID, userID).
+		Delete(&model.PostAuthor{}).Error
+}
+
+// ListAuthors 查询文章全部作者（所有者在前）。
+func (r *PostRepository) ListAuthors(ctx context.Context, postID uint) ([]model.PostAuthor, error) {
+	var authors []model.PostAuthor
+	if err := r.DB.WithContext(ctx).
+		Where("post_id = ?", postID).
+		Preload("User").
+		Order("CASE WHEN role = 'owner' THEN 0 ELSE 1 END, created_at ASC").
+		Find(&authors).Error; err != nil {
+		return nil, err
+	}
+	return authors, nil
+}
+
+// AddTransition 记录一次状态变更。
+func (r *PostRepository) AddTransition(ctx context.Context, t *model.PostTransition) error {
+	return r.DB.WithContext(ctx).Create(t).Error
+}
+
+// ListTransitions 按时间顺序查询文章的状态变更记录。
+func (r *PostRepository) ListTransitions(ctx context.Context, postID uint) ([]model.PostTransition, error) {
+	var list []model.PostTransition
+	if err := r.DB.WithContext(ctx).
+		Where("post_id = ?", postID).
+		Preload("User").
+		Order("id ASC").
+		Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// TrashFilter 回收站列表筛选条件。
+type TrashFilter struct {
+	UserID   *uint
+	Page     int
+	PageSize int
+}
+
+// ListTrashed 分页查询回收站中的文章，按删除时间倒序。
+func (r *PostRepository) ListTrashed(ctx context.Context, f TrashFilter) ([]model.Post, int64, error) {
+	db := r.DB.WithContext(ctx).Unscoped().Model(&model.Post{}).Where("deleted_at IS NOT NULL")
+	if f.UserID != nil {
+		db = db.Where("user_id = ?", *f.UserID)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	page := f.Page
+	if page <= 0 {
+		page = 1
+	}
+	pageSize := f.PageSize
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	var posts []model.Post
+	if err := db.Order("deleted_at DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&posts).Error; err != nil {
+		return nil, 0, err
+	}
+	return posts, total, nil
+}
+
+// FindTrashedByID 查询回收站中的文章。
+func (r *PostRepository) FindTrashedByID(ctx context.Context, id uint) (*model.Post, error) {
+	var post model.Post
+	if err := r.DB.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL").
+		First(&post, id).Error; err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// Restore 从回收站恢复文章。
+func (r *PostRepository) Restore(ctx context.Context, id uint) error {
+	return r.DB.WithContext(ctx).Unscoped().
+		Model(&model.Post{}).
+		Where("id = ?", id).
+		Update("deleted_at", nil).Error
+}
+
+// ListTrashedIDsBefore 查询删除时间早于 cutoff 的文章ID。
+func (r *PostRepository) ListTrashedIDsBefore(ctx context.Context, cutoff time.Time) ([]uint, error) {
+	var ids []uint
+	if err := r.DB.WithContext(ctx).Unscoped().
+		Model(&model.Post{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Purge 永久删除文章及其全部依赖数据：评论（含历史版本）、标签绑定、作者、表态、提及、通知、收藏、系列成员。
+func (r *PostRepository) Purge(ctx context.Context, id uint) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var commentIDs []uint
+		if err := tx.Unscoped().Model(&model.Comment{}).
+			Where("post_id = ?", id).
+			Pluck("id", &commentIDs).Error; err != nil {
+			return err
+		}
+		if err := purgeReactions(tx, model.ReactionTargetPost, []uint{id}); err != nil {
+			return err
+		}
+		if err := purgeReactions(tx, model.ReactionTargetComment, commentIDs); err != nil {
+			return err
+		}
+		if err := purgeMentions(tx, model.MentionTargetPost, []uint{id}); err != nil {
+			return err
+		}
+		if err := purgeMentions(tx, model.MentionTargetComment, commentIDs); err != nil {
+			return err
+		}
+		// 文章及其评论上的通知都带有 post_id
+		if err := purgeNotifications(tx, "post_id = ?", id); err != nil {
+			return err
+		}
+		if len(commentIDs) > 0 {
+			if err := tx.Where("comment_id IN ?", commentIDs).Delete(&model.CommentRevision{}).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Unscoped().Where("post_id = ?", id).Delete(&model.Comment{}).Error; err != nil {
+			return err
+		}
+		for _, dep := range []interface{}{
+			&model.PostTag{}, &model.PostAuthor{}, &model.PostViewer{}, &model.PreviewLink{},
+			&model.PostTransition{}, &model.Bookmark{}, &model.SeriesPost{},
+		} {
+			if err := tx.Where("post_id = ?", id).Delete(dep).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Delete(&model.Post{}, id).Error
+	})
+}
+
+// purgeReactions 删除目标上的表态记录与聚合计数。
+func purgeReactions(tx *gorm.DB, targetType string, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := tx.Where("target_type = ? AND target_id IN ?", targetType, ids).
+		Delete(&model.Reaction{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("target_type = ? AND target_id IN ?", targetType, ids).
+		Delete(&model.ReactionCount{}).Error
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go-blog/internal/model"
+	"gorm.io/gorm"
+)
+
+// PreviewRepository 负责草稿预览链接的存取。
+type PreviewRepository struct {
+	DB *gorm.DB
+}
+
+// NewPreviewRepository 创建预览链接仓库。
+func NewPreviewRepository(db *gorm.DB) *PreviewRepository {
+	return &PreviewRepository{DB: db}
+}
+
+// Create 新增预览链接。
+func (r *PreviewRepository) Create(ctx context.Context, l *model.PreviewLink) error {
+	return r.DB.WithContext(ctx).Create(l).Error
+}
+
+// FindByID 按ID查询预览链接。
+func (r *PreviewRepository) FindByID(ctx context.Context, id uint) (*model.PreviewLink, error) {
+	var l model.PreviewLink
+	if err := r.DB.WithContext(ctx).First(&l, id).Error; err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// FindByTokenID 按令牌 jti 查询预览链接。
+func (r *PreviewRepository) FindByTokenID(ctx context.Context, jti string) (*model.PreviewLink, error) {
+	var l model.PreviewLink
+	if err := r.DB.WithContext(ctx).Where("token_id = ?", jti).First(&l).Error; err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// ListActive 查询文章下未过期且未撤销的预览链接。
+func (r *PreviewRepository) ListActive(ctx context.Context, postID uint, now time.Time) ([]model.PreviewLink, error) {
+	var links []model.PreviewLink
+	if err := r.DB.WithContext(ctx).
+		Where("post_id = ? AND revoked_at IS NULL AND expires_at > ?", postID, now).
+		Order("created_at DESC").
+		Find(&links).Error; err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// Revoke 撤销预览链接。
+func (r *PreviewRepository) Revoke(ctx context.Context, id uint, at time.Time) error {
+	return r.DB.WithContext(ctx).
+		Model(&model.PreviewLink{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error
+}
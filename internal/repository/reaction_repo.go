@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+
+	"go-blog/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReactionRepository 负责表态记录与聚合计数的存取。
+type ReactionRepository struct {
+	DB *gorm.DB
+}
+
+// NewReactionRepository 创建表态仓库。
+func NewReactionRepository(db *gorm.DB) *ReactionRepository {
+	return &ReactionRepository{DB: db}
+}
+
+// Toggle 切换表态：已存在则取消，否则新增。返回切换后是否处于激活状态。
+// 计数只在记录真正插入/删除时增减，并发切换下与记录数保持一致。
+func (r *ReactionRepository) Toggle(ctx context.Context, uid uint, targetType string, targetID uint, emoji string) (bool, error) {
+	active := false
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing model.Reaction
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND target_type = ? AND target_id = ? AND emoji = ?", uid, targetType, targetID, emoji).
+			Limit(1).
+			Find(&existing)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected > 0 {
+			del := tx.Delete(&existing)
+			if del.Error != nil {
+				return del.Error
+			}
+			if del.RowsAffected == 0 {
+				return nil
+			}
+			return adjustReactionCount(tx, targetType, targetID, emoji, -1)
+		}
+
+		active = true
+		ins := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.Reaction{
+			UserId:     uid,
+			TargetType: targetType,
+			TargetId:   targetID,
+			Emoji:      emoji,
+		})
+		if ins.Error != nil {
+			return ins.Error
+		}
+		if ins.RowsAffected == 0 {
+			// 并发请求已插入同一表态，计数已由对方累加
+			return nil
+		}
+		return adjustReactionCount(tx, targetType, targetID, emoji, 1)
+	})
+	return active, err
+}
+
+// adjustReactionCount 原子增减聚合计数；文章点赞同步维护 posts.like_count。
+func adjustReactionCount(tx *gorm.DB, targetType string, targetID uint, emoji string, delta int64) error {
+	if delta > 0 {
+		if err := tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]interface{}{"count": gorm.Expr("count + ?", delta)}),
+		}).Create(&model.ReactionCount{
+			TargetType: targetType,
+			TargetId:   targetID,
+			Emoji:      emoji,
+			Count:      delta,
+		}).Error; err != nil {
+			return err
+		}
+	} else {
+		if err := tx.Model(&model.ReactionCount{}).
+			Where("target_type = ? AND target_id = ? AND emoji = ? AND count > 0", targetType, targetID, emoji).
+			UpdateColumn("count", gorm.Expr("count + ?", delta)).Error; err != nil {
+			return err
+		}
+	}
+
+	if targetType == model.ReactionTargetPost && emoji == "like" {
+		q := tx.Model(&model.Post{}).Where("id = ?", targetID)
+		if delta < 0 {
+			q = q.Where("like_count > 0")
+		}
+		return q.UpdateColumn("like_count", gorm.Expr("like_count + ?", delta)).Error
+	}
+	return nil
+}
+
+// CountsByTargets 批量查询目标的表态计数：目标ID -> 表情 -> 数量。
+func (r *ReactionRepository) CountsByTargets(ctx context.Context, targetType string, ids []uint) (map[uint]map[string]int64, error) {
+	out := make(map[uint]map[string]int64, len(ids))
+	if len(ids) == 0 {
+		return out, nil
+	}
+	var rows []model.ReactionCount
+	if err := r.DB.WithContext(ctx).
+		Where("target_type = ? AND target_id IN ? AND count > 0", targetType, ids).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if out[row.TargetId] == nil {
+			out[row.TargetId] = make(map[string]int64)
+		}
+		out[row.TargetId][row.Emoji] = row.Count
+	}
+	return out, nil
+}
+
+// UserReactions 批量查询某用户在目标上的表态：目标ID -> 表情列表。
+func (r *ReactionRepository) UserReactions(ctx context.Context, uid uint, targetType string, ids []uint) (map[uint][]string, error) {
+	out := make(map[uint][]string, len(ids))
+	if uid == 0 || len(ids) == 0 {
+		return out, nil
+	}
+	var rows []model.Reaction
+	if err := r.DB.WithContext(ctx).
+		Where("user_id = ? AND target_type = ? AND target_id IN ?", uid, targetType, ids).
+		Order("id ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		out[row.TargetId] = append(out[row.TargetId], row.Emoji)
+	}
+	return out, nil
+}
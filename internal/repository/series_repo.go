@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go-blog/internal/model"
+	"gorm.io/gorm"
+)
+
+// SeriesRepository 负责文章系列及其有序成员的存取。
+type SeriesRepository struct {
+	DB *gorm.DB
+}
+
+// NewSeriesRepository 创建系列仓库。
+func NewSeriesRepository(db *gorm.DB) *SeriesRepository {
+	return &SeriesRepository{DB: db}
+}
+
+// WithDB 返回使用指定连接（如事务）的仓库副本。
+func (r *SeriesRepository) WithDB(db *gorm.DB) *SeriesRepository {
+	return &SeriesRepository{DB: db}
+}
+
+// Create 新增系列。
+func (r *SeriesRepository) Create(ctx context.Context, s *model.Series) error {
+	return r.DB.WithContext(ctx).Create(s).Error
+}
+
+// Save 保存系列。
+func (r *SeriesRepository) Save(ctx context.Context, s *model.Series) error {
+	return r.DB.WithContext(ctx).Save(s).Error
+}
+
+// FindByID 按ID查询系列并预加载作者。
+func (r *SeriesRepository) FindByID(ctx context.Context, id uint) (*model.Series, error) {
+	var s model.Series
+	if err := r.DB.WithContext(ctx).Preload("User").First(&s, id).Error; err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Delete 删除系列及其成员关系（文章本身保留）。
+func (r *SeriesRepository) Delete(ctx context.Context, s *model.Series) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("series_id = ?", s.Id).Delete(&model.SeriesPost{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(s).Error
+	})
+}
+
+// SeriesFilter 系列列表筛选条件。
+type SeriesFilter struct {
+	UserID   *uint
+	Page     int
+	PageSize int
+}
+
+// List 按条件分页查询系列。
+func (r *SeriesRepository) List(ctx context.Context, f SeriesFilter) ([]model.Series, int64, error) {
+	db := r.DB.WithContext(ctx).Model(&model.Series{})
+	if f.UserID != nil && *f.UserID > 0 {
+		db = db.Where("user_id = ?", *f.UserID)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	page := f.Page
+	if page <= 0 {
+		page = 1
+	}
+	pageSize := f.PageSize
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	var list []model.Series
+	if err := db.Preload("User").
+		Order("updated_at DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+// ListParts 按顺序返回系列成员并预加载文章；回收站中的文章同样加载（DeletedAt 非空），由调用方区分。
+func (r *SeriesRepository) ListParts(ctx context.Context, seriesID uint) ([]model.SeriesPost, error) {
+	var parts []model.SeriesPost
+	if err := r.DB.WithContext(ctx).
+		Where("series_id = ?", seriesID).
+		Preload("Post", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Order("position ASC").
+		Find(&parts).Error; err != nil {
+		return nil, err
+	}
+	return parts, nil
+}
+
+// CountOtherSeries 统计给定文章中已属于其他系列的数量。
+func (r *SeriesRepository) CountOtherSeries(ctx context.Context, seriesID uint, postIDs []uint) (int64, error) {
+	var count int64
+	if len(postIDs) == 0 {
+		return 0, nil
+	}
+	if err := r.DB.WithContext(ctx).
+		Model(&model.SeriesPost{}).
+		Where("post_id IN ? AND series_id <> ?", postIDs, seriesID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ReplaceParts 以 postIDs 的顺序整体替换系列成员。
+func (r *SeriesRepository) ReplaceParts(ctx context.Context, seriesID uint, postIDs []uint) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("series_id = ?", seriesID).Delete(&model.SeriesPost{}).Error; err != nil {
+			return err
+		}
+		if len(postIDs) == 0 {
+			return nil
+		}
+		parts := make([]model.SeriesPost, 0, len(postIDs))
+		for i, pid := range postIDs {
+			parts = append(parts, model.SeriesPost{
+				SeriesId: seriesID,
+				PostId:   pid,
+				Position: i + 1,
+			})
+		}
+		if err := tx.Create(&parts).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.Series{}).Where("id = ?", seriesID).Update("updated_at", time.Now()).Error
+	})
+}
+
+// FindPartByPostID 查询文章所在的系列成员关系。
+func (r *SeriesRepository) FindPartByPostID(ctx context.Context, postID uint) (*model.SeriesPost, error) {
+	var part model.SeriesPost
+	if err := r.DB.WithContext(ctx).Where("post_id = ?", postID).First(&part).Error; err != nil {
+		return nil, err
+	}
+	return &part, nil
+}
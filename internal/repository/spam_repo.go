@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+
+	"go-blog/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SpamTokenRepository 维护贝叶斯分类器的词频表。
+type SpamTokenRepository struct {
+	DB *gorm.DB
+}
+
+// NewSpamTokenRepository 创建词频仓库。
+func NewSpamTokenRepository(db *gorm.DB) *SpamTokenRepository {
+	return &SpamTokenRepository{DB: db}
+}
+
+// Counts 查询词的计数，不存在的词不返回。
+func (r *SpamTokenRepository) Counts(ctx context.Context, tokens []string) ([]model.SpamToken, error) {
+	var rows []model.SpamToken
+	if len(tokens) == 0 {
+		return rows, nil
+	}
+	if err := r.DB.WithContext(ctx).Where("token IN ?", tokens).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// Add 批量累加词在垃圾或正常样本中的计数（不小于 0），不存在的词自动插入。
+func (r *SpamTokenRepository) Add(ctx context.Context, tokens []string, spam bool, delta int64) error {
+	if len(tokens) == 0 || delta == 0 {
+		return nil
+	}
+	col := "ham"
+	if spam {
+		col = "spam"
+	}
+	rows := make([]model.SpamToken, 0, len(tokens))
+	for _, t := range tokens {
+		row := model.SpamToken{Token: t}
+		if delta > 0 {
+			if spam {
+				row.Spam = delta
+			} else {
+				row.Ham = delta
+			}
+		}
+		rows = append(rows, row)
+	}
+	return r.DB.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "token"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				col:          gorm.Expr("GREATEST("+col+" + ?, 0)", delta),
+				"updated_at": gorm.Expr("VALUES(updated_at)"),
+			}),
+		}).
+		CreateInBatches(rows, 100).Error
+}
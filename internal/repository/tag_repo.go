@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"go-blog/internal/model"
 	"gorm.io/gorm"
@@ -32,3 +33,16 @@ func (r *TagRepository) ListOrdered(ctx context.Context) ([]model.Tag, error) {
 func (r *TagRepository) Create(ctx context.Context, tag *model.Tag) error {
 	return r.DB.WithContext(ctx).Create(tag).Error
 }
+
+// FindByNameOrSlug 按名称或 slug 查找标签（名称优先）。
+func (r *TagRepository) FindByNameOrSlug(ctx context.Context, name, slug string) (*model.Tag, error) {
+	var tag model.Tag
+	err := r.DB.WithContext(ctx).Where("name = ?", name).First(&tag).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = r.DB.WithContext(ctx).Where("slug = ?", slug).First(&tag).Error
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
@@ -32,6 +32,20 @@ func (r *UploadRepository) SaveFile(subDir, filename string, file *multipart.Fil
 	return filepath.ToSlash(filepath.Join(subDir, filename)), nil
 }
 
+// SaveBytes 将内存中的文件数据保存到指定子目录并返回相对路径。
+func (r *UploadRepository) SaveBytes(subDir, filename string, data []byte) (string, error) {
+	dstDir := filepath.Join(r.Root, subDir)
+	if err := os.MkdirAll(dstDir, 0o755); err != nil {
+		return "", err
+	}
+
+	if err := os.WriteFile(filepath.Join(dstDir, filename), data, 0o644); err != nil {
+		return "", err
+	}
+
+	return filepath.ToSlash(filepath.Join(subDir, filename)), nil
+}
+
 // writeMultipartFile 将 multipart 文件流写入目标路径。
 func writeMultipartFile(file *multipart.FileHeader, dst string) error {
 	src, err := file.Open()
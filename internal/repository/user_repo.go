@@ -36,6 +36,27 @@ func (r *UserRepository) FindByUsername(ctx context.Context, username string) (*
 	return &u, nil
 }
 
+// FindByUsernames 按用户名批量查询用户，不存在的用户名忽略。
+func (r *UserRepository) FindByUsernames(ctx context.Context, usernames []string) ([]model.User, error) {
+	var users []model.User
+	if len(usernames) == 0 {
+		return users, nil
+	}
+	if err := r.DB.WithContext(ctx).Where("username IN ?", usernames).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// FindByEmail 按邮箱查询用户。
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+	var u model.User
+	if err := r.DB.WithContext(ctx).Where("email = ?", email).First(&u).Error; err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
 // CountByUsernameOrEmail 统计用户名或邮箱是否已存在。
 func (r *UserRepository) CountByUsernameOrEmail(ctx context.Context, username, email string) (int64, error) {
 	var count int64
@@ -1,6 +1,9 @@
 package routes
 
 import (
+	"context"
+	"sync"
+
 	"go-blog/internal/handler"
 	"go-blog/internal/middleware"
 	"go-blog/internal/model"
@@ -10,10 +13,12 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-const uploadRoot = "storage/uploads"
+// UploadRoot 上传文件的保存根目录（命令行导入工具同样使用）。
+const UploadRoot = "storage/uploads"
 
-// SetupRouter 初始化 Gin 路由、中间件及依赖注入。
-func SetupRouter() *gin.Engine {
+// SetupRouter 初始化 Gin 路由、中间件及依赖注入，并启动后台任务；
+// 后台任务随 ctx 结束退出，返回的 wait 阻塞到它们全部退出（浏览量在退出前做最后一次落库）。
+func SetupRouter(ctx context.Context) (*gin.Engine, func()) {
 	// 自定义中间件链：Recovery -> Logger -> CORS
 	router := gin.New()
 	router.Use(middleware.Recovery(), middleware.LoggerMiddleware(), middleware.CORS())
@@ -23,18 +28,35 @@ func SetupRouter() *gin.Engine {
 
 	userRepo := repository.NewUserRepository(model.DB)
 	postRepo := repository.NewPostRepository(model.DB)
-	userSvc := service.NewUserService(userRepo, postRepo)
-	postSvc := service.NewPostService(model.DB, postRepo)
-	authSvc := service.NewAuthService(userRepo)
 	commentRepo := repository.NewCommentRepository(model.DB)
+	blockRepo := repository.NewBlockRepository(model.DB)
+	streamSvc := service.NewStreamService(service.LoadBroker())
+	notificationSvc := service.NewNotificationService(repository.NewNotificationRepository(model.DB), postRepo, commentRepo, blockRepo, streamSvc)
+	mentionSvc := service.NewMentionService(userRepo, repository.NewMentionRepository(model.DB), blockRepo, notificationSvc)
+	reactionRepo := repository.NewReactionRepository(model.DB)
+	reactionSvc := service.NewReactionService(reactionRepo, postRepo, commentRepo, notificationSvc)
+	bookmarkRepo := repository.NewBookmarkRepository(model.DB)
+	userSvc := service.NewUserService(userRepo, postRepo, commentRepo, bookmarkRepo, blockRepo)
+	bookmarkSvc := service.NewBookmarkService(bookmarkRepo, postRepo)
+	seriesRepo := repository.NewSeriesRepository(model.DB)
+	seriesSvc := service.NewSeriesService(model.DB, seriesRepo, postRepo)
+	viewCounter := service.NewViewCounter(postRepo)
 	categoryRepo := repository.NewCategoryRepository(model.DB)
+	relatedSvc := service.NewRelatedService(postRepo, categoryRepo)
+	spamCheckers := service.LoadSpamCheckers(repository.NewSpamTokenRepository(model.DB))
+	postSvc := service.NewPostService(model.DB, postRepo, userRepo, viewCounter, reactionSvc, seriesSvc, relatedSvc, spamCheckers.Posts, mentionSvc, notificationSvc)
+	hotRanker := service.NewHotRanker(postRepo)
+	authSvc := service.NewAuthService(userRepo)
 	tagRepo := repository.NewTagRepository(model.DB)
-	uploadRepo := repository.NewUploadRepository(uploadRoot)
-	commentSvc := service.NewCommentService(commentRepo, postRepo)
+	uploadRepo := repository.NewUploadRepository(UploadRoot)
+	commentSvc := service.NewCommentService(commentRepo, postRepo, userRepo, reactionSvc, spamCheckers.Comments, mentionSvc, notificationSvc, streamSvc)
 	categorySvc := service.NewCategoryService(categoryRepo)
 	tagSvc := service.NewTagService(tagRepo)
 	uploadSvc := service.NewUploadService(uploadRepo)
 	adminSvc := service.NewAdminService(userRepo, postRepo, commentRepo)
+	trashSvc := service.NewTrashService(postRepo, commentRepo, relatedSvc)
+	previewSvc := service.NewPreviewService(repository.NewPreviewRepository(model.DB), postRepo)
+	importSvc := service.NewImportService(model.DB, userRepo, postRepo, categoryRepo, tagRepo, commentRepo, repository.NewImportRepository(model.DB), uploadSvc, relatedSvc)
 
 	uh := handler.NewUserHandler(userSvc)
 	ph := handler.NewPostHandler(postSvc)
@@ -44,6 +66,25 @@ func SetupRouter() *gin.Engine {
 	th := handler.NewTagHandler(tagSvc)
 	fh := handler.NewUploadHandler(uploadSvc)
 	adh := handler.NewAdminHandler(adminSvc)
+	rh := handler.NewReactionHandler(reactionSvc)
+	bh := handler.NewBookmarkHandler(bookmarkSvc)
+	sh := handler.NewSeriesHandler(seriesSvc)
+	trh := handler.NewTrashHandler(trashSvc)
+	pvh := handler.NewPreviewHandler(previewSvc)
+	imh := handler.NewImportHandler(importSvc)
+	mdh := handler.NewModerationHandler(commentSvc)
+	nh := handler.NewNotificationHandler(notificationSvc)
+	sth := handler.NewStreamHandler(streamSvc, commentSvc)
+
+	// 后台任务：浏览量定期批量落库、热度分定期重算、回收站过期清理
+	var wg sync.WaitGroup
+	for _, run := range []func(context.Context){viewCounter.Run, hotRanker.Run, trashSvc.Run} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			run(ctx)
+		}()
+	}
 
 	// 分组：/api/auth
 	apiAuth := router.Group("/api/auth")
@@ -58,19 +99,70 @@ func SetupRouter() *gin.Engine {
 	api.Use(middleware.AuthMiddleware(), middleware.RequireUser())
 	{
 		api.GET("/me", uh.MeHandler)
+		api.GET("/me/export", uh.ExportData)
+		api.GET("/me/blocks", uh.ListBlocked)
+		api.GET("/me/notifications", nh.ListNotifications)
+		api.GET("/me/notifications/unread-count", nh.UnreadCount)
+		api.POST("/me/notifications/read-all", nh.MarkAllRead)
+		api.POST("/me/notifications/:id/read", nh.MarkRead)
+		api.GET("/me/notification-preferences", nh.GetPreferences)
+		api.PUT("/me/notification-preferences", nh.UpdatePreferences)
+		api.GET("/me/trash/posts", trh.MyTrashedPosts)
+		api.GET("/me/trash/comments", trh.MyTrashedComments)
 
 		api.POST("/posts", ph.CreatePost)
 		api.GET("/posts", ph.GetAllPosts)
 		api.GET("/posts/:id", ph.GetPostsById)
 		api.PUT("/posts/:id", ph.UpdatePost)
 		api.DELETE("/posts/:id", ph.DeletePost)
+		api.POST("/posts/:id/restore", trh.RestorePost)
+		api.GET("/posts/:id/related", ph.RelatedPosts)
+		api.GET("/posts/:id/authors", ph.ListAuthors)
+		api.POST("/posts/:id/authors", ph.AddCoAuthor)
+		api.DELETE("/posts/:id/authors/:user_id", ph.RemoveCoAuthor)
+		api.GET("/posts/:id/transitions", ph.ListTransitions)
+		api.POST("/posts/:id/transitions", ph.TransitionPost)
+		api.GET("/reviews", middleware.RequireRole(postSvc.Workflow.ReviewerRoles...), ph.ReviewQueue)
+		api.GET("/posts/:id/previews", pvh.ListPreviews)
+		api.POST("/posts/:id/previews", pvh.CreatePreview)
+		api.DELETE("/posts/:id/previews/:preview_id", pvh.RevokePreview)
 
 		api.POST("/comments", ch.CreateComment)
 		api.POST("/comments/:id/reply", ch.ReplyComment)
+		api.PUT("/comments/:id", ch.UpdateComment)
 		api.DELETE("/comments/:id", ch.DeleteComment)
+		api.POST("/comments/:id/restore", trh.RestoreComment)
 		api.GET("/posts/:id/comments", ch.ListCommentsByPost)
+		api.GET("/comments/:id", ch.GetComment)
+		api.GET("/comments/:id/replies", ch.ListReplies)
+		api.GET("/comments/:id/revisions", middleware.RequireRole(commentSvc.Moderation.ModeratorRoles...), ch.ListRevisions)
+
+		api.GET("/reactions/emojis", rh.ListEmojis)
+		api.POST("/posts/:id/reactions", rh.TogglePostReaction)
+		api.POST("/comments/:id/reactions", rh.ToggleCommentReaction)
+
+		api.POST("/posts/:id/bookmark", bh.AddBookmark)
+		api.DELETE("/posts/:id/bookmark", bh.RemoveBookmark)
+		api.GET("/me/bookmarks", bh.ListBookmarks)
+		api.GET("/me/reading-lists", bh.ListReadingLists)
+		api.POST("/me/reading-lists", bh.CreateReadingList)
+		api.PUT("/me/reading-lists/:id", bh.UpdateReadingList)
+		api.DELETE("/me/reading-lists/:id", bh.DeleteReadingList)
 
 		api.GET("/users/:id/posts", uh.ListUserPosts)
+		api.POST("/users/:id/block", uh.BlockUser)
+		api.DELETE("/users/:id/block", uh.UnblockUser)
+
+		api.GET("/archive", ph.Archive)
+		api.GET("/archive/:year", ph.ArchivePosts)
+		api.GET("/archive/:year/:month", ph.ArchivePosts)
+
+		api.GET("/series", sh.ListSeries)
+		api.POST("/series", sh.CreateSeries)
+		api.GET("/series/:id", sh.GetSeries)
+		api.PUT("/series/:id", sh.UpdateSeries)
+		api.PUT("/series/:id/posts", sh.SetSeriesPosts)
+		api.DELETE("/series/:id", sh.DeleteSeries)
 
 		api.GET("/categories", gh.ListCategories)
 		api.POST("/categories", gh.CreateCategory)
@@ -81,7 +173,32 @@ func SetupRouter() *gin.Engine {
 		api.POST("/upload", fh.UploadSingle)
 		api.POST("/upload/multi", fh.UploadMulti)
 	}
-	router.Static("/static/uploads", "./"+uploadRoot)
+	router.Static("/static/uploads", "./"+UploadRoot)
+
+	// 分组：/api 实时推送（SSE，鉴权；令牌也可通过 access_token 查询参数携带）
+	stream := router.Group("/api")
+	stream.Use(middleware.QueryToken(), middleware.AuthMiddleware(), middleware.RequireUser())
+	{
+		stream.GET("/posts/:id/stream", sth.PostComments)
+		stream.GET("/me/notifications/stream", sth.Notifications)
+	}
+
+	// 分组：/api/shared（公开分享，无需登录）
+	shared := router.Group("/api/shared")
+	{
+		shared.GET("/reading-lists/:token", bh.SharedReadingList)
+		shared.GET("/previews/:token", pvh.OpenPreview)
+	}
+
+	// 分组：/api/moderation（鉴权+审核员角色）
+	moderation := router.Group("/api/moderation")
+	moderation.Use(middleware.AuthMiddleware(), middleware.RequireUser(), middleware.RequireRole(commentSvc.Moderation.ModeratorRoles...))
+	{
+		moderation.GET("/comments", mdh.ListQueue)
+		moderation.POST("/comments/approve", mdh.Approve)
+		moderation.POST("/comments/reject", mdh.Reject)
+		moderation.POST("/comments/spam", mdh.MarkSpam)
+	}
 
 	// 分组：/api/admin（鉴权+RBAC）
 	admin := router.Group("/api/admin")
@@ -91,7 +208,14 @@ func SetupRouter() *gin.Engine {
 		admin.GET("/users", adh.ListUsers)
 		admin.GET("/posts", adh.ListPosts)
 		admin.GET("/comments", adh.ListComments)
+		admin.GET("/trash/posts", trh.AdminTrashedPosts)
+		admin.GET("/trash/comments", trh.AdminTrashedComments)
+		admin.DELETE("/trash/posts/:id", trh.PurgePost)
+		admin.DELETE("/trash/comments/:id", trh.PurgeComment)
+		admin.POST("/import/markdown", imh.ImportMarkdown)
+		admin.POST("/import/wordpress", imh.ImportWordPress)
+		admin.POST("/import/disqus", imh.ImportDisqus)
 	}
 
-	return router
+	return router, wg.Wait
 }
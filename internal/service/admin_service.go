@@ -44,6 +44,11 @@ func (s *AdminService) Dashboard(ctx context.Context, topN int) (*dto.AdminDashb
 		return nil, err
 	}
 
+	viewCount, err := s.postRepo.SumViews(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	newUsers, err := s.userRepo.CountSince(ctx, since)
 	if err != nil {
 		return nil, err
@@ -73,11 +78,26 @@ func (s *AdminService) Dashboard(ctx context.Context, topN int) (*dto.AdminDashb
 		})
 	}
 
+	topViewStats, err := s.postRepo.TopPostsByViews(ctx, topN)
+	if err != nil {
+		return nil, err
+	}
+
+	topViews := make([]dto.AdminTopViewedPost, 0, len(topViewStats))
+	for _, stat := range topViewStats {
+		topViews = append(topViews, dto.AdminTopViewedPost{
+			PostID:    stat.PostID,
+			Title:     stat.Title,
+			ViewCount: stat.ViewCount,
+		})
+	}
+
 	return &dto.AdminDashboardResp{
 		Metrics: dto.AdminDashboardMetrics{
 			Users:    userCount,
 			Posts:    postCount,
 			Comments: commentCount,
+			Views:    viewCount,
 		},
 		Recent: dto.AdminRecentStats{
 			NewUsers:    newUsers,
@@ -85,6 +105,7 @@ func (s *AdminService) Dashboard(ctx context.Context, topN int) (*dto.AdminDashb
 			NewComments: newComments,
 		},
 		TopPosts: topPosts,
+		TopViews: topViews,
 	}, nil
 }
 
@@ -99,7 +120,7 @@ func (s *AdminService) ListUsers(ctx context.Context, q dto.AdminUserQuery) ([]m
 	return s.userRepo.List(ctx, filter)
 }
 
-// ListPosts 按状态、关键词等条件分页返回文章列表。
+// ListPosts 按状态、关键词等条件分页返回文章列表；私密与密码保护文章不返回正文。
 func (s *AdminService) ListPosts(ctx context.Context, q dto.AdminPostQuery) ([]model.Post, int64, error) {
 	filter := repository.PostFilter{
 		Keyword:  q.Keyword,
@@ -108,7 +129,16 @@ func (s *AdminService) ListPosts(ctx context.Context, q dto.AdminPostQuery) ([]m
 		PageSize: q.PageSize,
 		Order:    "latest",
 	}
-	return s.postRepo.ListPosts(ctx, filter)
+	posts, total, err := s.postRepo.ListPosts(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range posts {
+		if posts[i].Visibility == model.PostVisibilityPrivate || posts[i].Visibility == model.PostVisibilityPassword {
+			posts[i].Content = ""
+		}
+	}
+	return posts, total, nil
 }
 
 // ListComments 按用户/文章和关键词过滤评论并分页返回。
@@ -117,6 +147,7 @@ func (s *AdminService) ListComments(ctx context.Context, q dto.AdminCommentQuery
 		UserID:   q.UserID,
 		PostID:   q.PostID,
 		Keyword:  q.Keyword,
+		Status:   q.Status,
 		Page:     q.Page,
 		PageSize: q.PageSize,
 	}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-blog/internal/dto"
+	"go-blog/internal/model"
+	"go-blog/internal/repository"
+)
+
+// ErrInvalidArchiveDate 归档年月不合法。
+var ErrInvalidArchiveDate = errors.New("invalid archive date")
+
+// Archive 按年、月聚合当前用户可见的已发布文章数，可按分类与标签筛选，用于侧边栏归档。
+func (s *PostService) Archive(ctx context.Context, uid uint, categoryID *uint, tagIDs []uint) ([]dto.ArchiveYearResp, error) {
+	stats, err := s.Repo.ArchiveCounts(ctx, uid, categoryID, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+	years := make([]dto.ArchiveYearResp, 0)
+	for _, st := range stats {
+		if len(years) == 0 || years[len(years)-1].Year != st.Year {
+			years = append(years, dto.ArchiveYearResp{Year: st.Year, Months: []dto.ArchiveMonthResp{}})
+		}
+		y := &years[len(years)-1]
+		y.Count += st.Count
+		y.Months = append(y.Months, dto.ArchiveMonthResp{Month: st.Month, Count: st.Count})
+	}
+	return years, nil
+}
+
+// ArchivePosts 分页列出某年某月发布的文章（month 为 0 时列出全年），沿用列表的分类与标签筛选。
+func (s *PostService) ArchivePosts(ctx context.Context, uid uint, year, month int, f repository.PostFilter) ([]model.Post, int64, error) {
+	if year < 1970 || year > 9999 || month < 0 || month > 12 {
+		return nil, 0, ErrInvalidArchiveDate
+	}
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(1, 0, 0)
+	if month > 0 {
+		from = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+		to = from.AddDate(0, 1, 0)
+	}
+	status := model.PostStatusPublished
+	f.Status = &status
+	f.From = &from
+	f.To = &to
+	f.Keyword = ""
+	f.Order = "latest"
+	return s.ListPosts(ctx, uid, f)
+}
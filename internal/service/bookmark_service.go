@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"go-blog/internal/dto"
+	"go-blog/internal/model"
+	"go-blog/internal/repository"
+	"go-blog/internal/util"
+	"gorm.io/gorm"
+)
+
+// 收藏与阅读清单相关错误定义。
+var (
+	ErrBookmarkNotFound     = errors.New("bookmark not found")
+	ErrReadingListNotFound  = errors.New("reading list not found")
+	ErrReadingListForbidden = errors.New("reading list forbidden")
+)
+
+// SharedReadingListPath 公开阅读清单的分享路径前缀。
+const SharedReadingListPath = "/api/shared/reading-lists/"
+
+// BookmarkService 处理收藏与阅读清单业务。
+type BookmarkService struct {
+	repo     *repository.BookmarkRepository
+	postRepo *repository.PostRepository
+}
+
+// NewBookmarkService 构造收藏服务。
+func NewBookmarkService(repo *repository.BookmarkRepository, postRepo *repository.PostRepository) *BookmarkService {
+	return &BookmarkService{repo: repo, postRepo: postRepo}
+}
+
+// AddBookmark 收藏文章；已收藏时仅调整所属清单。
+func (s *BookmarkService) AddBookmark(ctx context.Context, uid, postID uint, req dto.AddBookmarkReq) (*model.Bookmark, error) {
+	if err := s.authorizeBookmark(ctx, uid, postID); err != nil {
+		return nil, err
+	}
+	if req.ReadingListId != nil {
+		if _, err := s.ownedList(ctx, uid, *req.ReadingListId); err != nil {
+			return nil, err
+		}
+	}
+
+	b, err := s.repo.FindByUserPost(ctx, uid, postID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if b != nil {
+		b.ReadingListId = req.ReadingListId
+		if err := s.repo.Save(ctx, b); err != nil {
+			return nil, err
+		}
+		return b, nil
+	}
+
+	b = &model.Bookmark{
+		UserId:        uid,
+		PostId:        postID,
+		ReadingListId: req.ReadingListId,
+	}
+	if err := s.repo.Create(ctx, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// RemoveBookmark 取消收藏。
+func (s *BookmarkService) RemoveBookmark(ctx context.Context, uid, postID uint) error {
+	n, err := s.repo.DeleteByUserPost(ctx, uid, postID)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrBookmarkNotFound
+	}
+	return nil
+}
+
+// ListBookmarks 分页返回当前用户的收藏，可按阅读清单筛选。
+func (s *BookmarkService) ListBookmarks(ctx context.Context, uid uint, listID *uint, page, pageSize int) ([]model.Bookmark, int64, error) {
+	if listID != nil {
+		if _, err := s.ownedList(ctx, uid, *listID); err != nil {
+			return nil, 0, err
+		}
+	}
+	bookmarks, total, err := s.repo.List(ctx, repository.BookmarkFilter{
+		UserID:        uid,
+		ReadingListID: listID,
+		ViewerID:      uid,
+		Page:          page,
+		PageSize:      pageSize,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	maskBookmarked(uid, bookmarks)
+	return bookmarks, total, nil
+}
+
+// CreateReadingList 创建阅读清单并生成分享令牌。
+func (s *BookmarkService) CreateReadingList(ctx context.Context, uid uint, req dto.CreateReadingListReq) (*model.ReadingList, error) {
+	token, err := util.RandomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	visibility := req.Visibility
+	if visibility == "" {
+		visibility = model.ReadingListPrivate
+	}
+	l := &model.ReadingList{
+		UserId:      uid,
+		Name:        req.Name,
+		Description: req.Description,
+		Visibility:  visibility,
+		ShareToken:  token,
+	}
+	if err := s.repo.CreateList(ctx, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// UpdateReadingList 更新阅读清单，仅所有者可操作。
+func (s *BookmarkService) UpdateReadingList(ctx context.Context, uid, id uint, req dto.UpdateReadingListReq) (*model.ReadingList, error) {
+	l, err := s.ownedList(ctx, uid, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		l.Name = *req.Name
+	}
+	if req.Description != nil {
+		l.Description = *req.Description
+	}
+	if req.Visibility != nil {
+		l.Visibility = *req.Visibility
+	}
+	if err := s.repo.SaveList(ctx, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// DeleteReadingList 删除阅读清单，仅所有者可操作。
+func (s *BookmarkService) DeleteReadingList(ctx context.Context, uid, id uint) error {
+	l, err := s.ownedList(ctx, uid, id)
+	if err != nil {
+		return err
+	}
+	return s.repo.DeleteList(ctx, l)
+}
+
+// ListReadingLists 返回当前用户的阅读清单。
+func (s *BookmarkService) ListReadingLists(ctx context.Context, uid uint) ([]model.ReadingList, error) {
+	return s.repo.ListListsByUser(ctx, uid)
+}
+
+// GetSharedReadingList 通过分享令牌访问公开清单，仅返回对匿名访客可见的已发布文章。
+func (s *BookmarkService) GetSharedReadingList(ctx context.Context, token string, page, pageSize int) (*model.ReadingList, []model.Bookmark, int64, error) {
+	l, err := s.repo.FindListByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, 0, ErrReadingListNotFound
+		}
+		return nil, nil, 0, err
+	}
+	if l.Visibility != model.ReadingListPublic {
+		return nil, nil, 0, ErrReadingListNotFound
+	}
+	bookmarks, total, err := s.repo.List(ctx, repository.BookmarkFilter{
+		ReadingListID: &l.Id,
+		PublishedOnly: true,
+		Page:          page,
+		PageSize:      pageSize,
+	})
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	maskBookmarked(0, bookmarks)
+	return l, bookmarks, total, nil
+}
+
+// authorizeBookmark 校验 uid 能否收藏文章，规则同 authorizePostInteract；
+// 密码保护文章的标题本就公开列出，收藏时无需密码。不可读时统一返回 ErrPostNotFound。
+func (s *BookmarkService) authorizeBookmark(ctx context.Context, uid, postID uint) error {
+	post, err := s.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPostNotFound
+		}
+		return err
+	}
+	if err := authorizePostInteract(ctx, s.postRepo, post, uid, ""); err != nil && !errors.Is(err, ErrPostPasswordRequired) {
+		return err
+	}
+	return nil
+}
+
+// ownedList 查询阅读清单并校验归属。
+func (s *BookmarkService) ownedList(ctx context.Context, uid, id uint) (*model.ReadingList, error) {
+	l, err := s.repo.FindListByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReadingListNotFound
+		}
+		return nil, err
+	}
+	if l.UserId != uid {
+		return nil, ErrReadingListForbidden
+	}
+	return l, nil
+}
+
+// maskBookmarked 对非作者隐藏收藏中密码保护文章的正文。
+func maskBookmarked(uid uint, bookmarks []model.Bookmark) {
+	for i := range bookmarks {
+		maskProtected(uid, &bookmarks[i].Post)
+	}
+}
+
+// ToBookmarkResps 将收藏转换为响应结构。
+func ToBookmarkResps(bookmarks []model.Bookmark) []dto.BookmarkResp {
+	resp := make([]dto.BookmarkResp, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		item := dto.BookmarkResp{
+			Id:            b.Id,
+			PostId:        b.PostId,
+			ReadingListId: b.ReadingListId,
+			CreatedAt:     b.CreatedAt,
+			Post: dto.PostBrief{
+				Id:     b.Post.ID,
+				Title:  b.Post.Title,
+				Status: b.Post.Status,
+			},
+		}
+		if b.Post.User != nil {
+			item.Post.User = dto.UserBrief{Id: b.Post.User.ID, Username: b.Post.User.Username}
+		}
+		resp = append(resp, item)
+	}
+	return resp
+}
+
+// ToReadingListResp 将阅读清单转换为响应结构，公开清单附带分享地址。
+func ToReadingListResp(l model.ReadingList) dto.ReadingListResp {
+	resp := dto.ReadingListResp{
+		Id:          l.Id,
+		Name:        l.Name,
+		Description: l.Description,
+		Visibility:  l.Visibility,
+		CreatedAt:   l.CreatedAt,
+		UpdatedAt:   l.UpdatedAt,
+	}
+	if l.Visibility == model.ReadingListPublic {
+		resp.ShareURL = SharedReadingListPath + l.ShareToken
+	}
+	return resp
+}
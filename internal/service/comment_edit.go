@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-blog/internal/dto"
+	"go-blog/internal/model"
+	"go-blog/internal/util"
+	"gorm.io/gorm"
+)
+
+// 评论编辑相关错误定义。
+var (
+	ErrCommentEditExpired  = errors.New("comment edit window expired")
+	ErrCommentEditConflict = errors.New("comment status changed during edit")
+)
+
+// LoadCommentEditWindow 读取作者可编辑评论的时间窗口 COMMENT_EDIT_WINDOW（分钟，默认 15，0 表示作者不可编辑）。
+func LoadCommentEditWindow() time.Duration {
+	return util.EnvMinutes("COMMENT_EDIT_WINDOW", 15)
+}
+
+// UpdateComment 编辑评论：作者在发表后的编辑窗口内可编辑，审核员随时可编辑；
+// 编辑前的内容保存为历史版本。作者的编辑按新建评论的规则重新判定状态（垃圾检测与文章审核策略），
+// 已通过的评论可能因此回到待审核或垃圾状态，并从实时流中移除。
+func (s *CommentService) UpdateComment(ctx context.Context, uid uint, role string, id uint, req dto.UpdateCommentReq) (*model.Comment, error) {
+	comment, err := s.commentRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	if comment.Tombstone {
+		return nil, ErrCommentNotFound
+	}
+	moderator := s.Moderation.IsModerator(role)
+	if !moderator {
+		if comment.UserId != uid {
+			if !comment.VisibleTo(uid) {
+				return nil, ErrCommentNotFound
+			}
+			return nil, ErrCommentForbidden
+		}
+		if time.Since(comment.CreatedAt) > s.EditWindow {
+			return nil, ErrCommentEditExpired
+		}
+	}
+	if req.Content == comment.Content {
+		return comment, nil
+	}
+
+	rev := &model.CommentRevision{
+		CommentId: comment.Id,
+		EditorId:  uid,
+		Content:   comment.Content,
+	}
+	post, err := s.postRepo.FindByID(ctx, comment.PostId)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	prevStatus := comment.Status
+	wasApproved := prevStatus == model.CommentStatusApproved
+	comment.Content = req.Content
+	comment.EditedAt = &now
+	if !moderator {
+		comment.IP, comment.UserAgent = req.IP, truncate(req.UserAgent, 255)
+		status, err := s.initialStatus(ctx, comment, role, post)
+		if err != nil {
+			return nil, err
+		}
+		// 编辑只会让评论重新进入审核或被判为垃圾，不会让待审核、已拒绝的评论直接通过
+		if status == model.CommentStatusSpam || (status == model.CommentStatusPending && wasApproved) {
+			comment.Status = status
+		}
+	}
+	// 仅在状态未被审核员同时修改时保存，避免覆盖审核决定
+	saved, err := s.commentRepo.Edit(ctx, comment, prevStatus, rev)
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		return nil, ErrCommentEditConflict
+	}
+	s.syncMentions(ctx, comment, post)
+	switch {
+	case comment.Status == model.CommentStatusApproved:
+		s.broadcast(ctx, StreamCommentUpdated, comment.Id)
+	case wasApproved:
+		s.broadcastDeleted(ctx, comment)
+	}
+	return comment, nil
+}
+
+// ListRevisions 返回评论的当前内容与历史版本（最新的在前），供审核员查看。
+func (s *CommentService) ListRevisions(ctx context.Context, id uint) (*dto.CommentRevisionsResp, error) {
+	comment, err := s.commentRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	revs, err := s.commentRepo.ListRevisions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	resp := &dto.CommentRevisionsResp{
+		Id:        comment.Id,
+		Content:   comment.Content,
+		EditedAt:  comment.EditedAt,
+		Revisions: make([]dto.CommentRevisionResp, 0, len(revs)),
+	}
+	for _, r := range revs {
+		item := dto.CommentRevisionResp{Id: r.Id, Content: r.Content, CreatedAt: r.CreatedAt}
+		if r.Editor != nil {
+			item.Editor = dto.UserBrief{Id: r.Editor.ID, Username: r.Editor.Username}
+		}
+		resp.Revisions = append(resp.Revisions, item)
+	}
+	return resp, nil
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"go-blog/internal/dto"
+	"go-blog/internal/model"
+	"go-blog/internal/repository"
+	"go-blog/internal/util"
+)
+
+// ErrInvalidModeration 审核策略或目标状态不合法。
+var ErrInvalidModeration = errors.New("invalid comment moderation")
+
+// ModerationPolicy 评论审核配置：站点默认策略与可审核评论的角色。
+// 文章可单独设置策略（posts.comment_moderation），为空时沿用站点默认。
+type ModerationPolicy struct {
+	Default        string
+	ModeratorRoles []string
+}
+
+// LoadModerationPolicy 从环境变量读取审核配置：COMMENT_MODERATION、COMMENT_MODERATOR_ROLES。
+func LoadModerationPolicy() ModerationPolicy {
+	var roles []string
+	for _, r := range strings.Split(util.EnvString("COMMENT_MODERATOR_ROLES", "admin,moderator"), ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			roles = append(roles, r)
+		}
+	}
+	def := util.EnvString("COMMENT_MODERATION", model.CommentModerationNone)
+	if !ValidModeration(def) {
+		def = model.CommentModerationNone
+	}
+	return ModerationPolicy{Default: def, ModeratorRoles: roles}
+}
+
+// ValidModeration 判断审核策略取值是否合法。
+func ValidModeration(mode string) bool {
+	switch mode {
+	case model.CommentModerationAll, model.CommentModerationFirstTime, model.CommentModerationNone:
+		return true
+	}
+	return false
+}
+
+// IsModerator 判断角色是否可以审核评论。
+func (p ModerationPolicy) IsModerator(role string) bool {
+	for _, r := range p.ModeratorRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// For 返回文章实际生效的审核策略。
+func (p ModerationPolicy) For(post *model.Post) string {
+	if post.CommentModeration != "" {
+		return post.CommentModeration
+	}
+	return p.Default
+}
+
+// initialStatus 决定新评论（或作者编辑后的评论）的状态：审核员与文章所有者的评论直接通过；
+// 被垃圾检测器判定为垃圾的进入 spam；其余按文章的审核策略决定。
+func (s *CommentService) initialStatus(ctx context.Context, comment *model.Comment, role string, post *model.Post) (string, error) {
+	uid := comment.UserId
+	if s.Moderation.IsModerator(role) || post.UserID == uid {
+		return model.CommentStatusApproved, nil
+	}
+	if s.spamChecker != nil {
+		content, err := s.commentSpamContentFor(ctx, comment)
+		if err != nil {
+			return "", err
+		}
+		res, err := s.spamChecker.Check(ctx, content)
+		if err != nil {
+			return "", err
+		}
+		if res.Spam {
+			log.Printf("comment by user %d marked as spam by %s: %s", uid, res.Checker, res.Reason)
+			return model.CommentStatusSpam, nil
+		}
+	}
+	switch s.Moderation.For(post) {
+	case model.CommentModerationAll:
+		return model.CommentStatusPending, nil
+	case model.CommentModerationFirstTime:
+		n, err := s.commentRepo.CountApprovedByUser(ctx, uid)
+		if err != nil {
+			return "", err
+		}
+		// 重新判定已通过的评论（编辑）时，不把它自身算作此前通过的评论
+		if comment.Id != 0 && comment.Status == model.CommentStatusApproved {
+			n--
+		}
+		if n <= 0 {
+			return model.CommentStatusPending, nil
+		}
+	}
+	return model.CommentStatusApproved, nil
+}
+
+// ModerationQueue 分页查询指定状态的评论（默认待审核），按时间倒序。
+func (s *CommentService) ModerationQueue(ctx context.Context, status string, postID *uint, page, pageSize int) ([]dto.ModerationCommentResp, int64, error) {
+	if status == "" {
+		status = model.CommentStatusPending
+	}
+	if !validCommentStatus(status) {
+		return nil, 0, ErrInvalidModeration
+	}
+	comments, total, err := s.commentRepo.List(ctx, repository.CommentFilter{
+		PostID:   postID,
+		Status:   status,
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	list := make([]dto.ModerationCommentResp, 0, len(comments))
+	for _, c := range comments {
+		list = append(list, dto.ModerationCommentResp{
+			Id:        c.Id,
+			Content:   c.Content,
+			Status:    c.Status,
+			User:      dto.UserBrief{Id: c.User.ID, Username: c.User.Username},
+			PostId:    c.PostId,
+			PostTitle: c.Post.Title,
+			ParentId:  c.ParentId,
+			CreatedAt: c.CreatedAt,
+		})
+	}
+	return list, total, nil
+}
+
+// ModerateComments 批量设置评论审核状态（通过 / 拒绝 / 标记垃圾），返回实际变更的条数。
+func (s *CommentService) ModerateComments(ctx context.Context, ids []uint, status string) (int64, error) {
+	if status == model.CommentStatusPending || !validCommentStatus(status) {
+		return 0, ErrInvalidModeration
+	}
+	before, err := s.commentRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return 0, err
+	}
+	n, err := s.commentRepo.UpdateStatus(ctx, ids, status)
+	if err != nil {
+		return 0, err
+	}
+	s.afterModeration(ctx, before, status)
+	// 外部检测服务可能较慢，训练在后台进行
+	go s.trainSpam(context.Background(), ids, status)
+	return n, nil
+}
+
+// afterModeration 通知作者审核结果（状态未变化的不通知）；新通过审核的评论补发回复、评论与提及通知。
+func (s *CommentService) afterModeration(ctx context.Context, comments []model.Comment, status string) {
+	posts := make(map[uint]*model.Post)
+	var publish []uint
+	for i := range comments {
+		c := &comments[i]
+		if c.Status == status || c.Tombstone {
+			continue
+		}
+		if c.Status == model.CommentStatusApproved {
+			s.broadcastDeleted(ctx, c)
+		}
+		s.notifier.Notify(ctx, NotificationEvent{
+			Type:       model.NotificationModeration,
+			UserId:     c.UserId,
+			TargetType: model.MentionTargetComment,
+			TargetId:   c.Id,
+			PostId:     c.PostId,
+			Detail:     status,
+		})
+		if status != model.CommentStatusApproved {
+			continue
+		}
+		post, ok := posts[c.PostId]
+		if !ok {
+			var err error
+			if post, err = s.postRepo.FindByID(ctx, c.PostId); err != nil {
+				log.Printf("load post %d after moderation error: %v", c.PostId, err)
+				post = nil
+			}
+			posts[c.PostId] = post
+		}
+		if post == nil {
+			continue
+		}
+		c.Status = status
+		s.notifier.CommentPublished(ctx, c, post)
+		s.broadcast(ctx, StreamCommentCreated, c.Id)
+		if mentionsPublic(post) {
+			publish = append(publish, c.Id)
+		}
+	}
+	if s.mentions != nil && len(publish) > 0 {
+		if err := s.mentions.Publish(ctx, model.MentionTargetComment, publish...); err != nil {
+			log.Printf("publish comment mentions error: %v", err)
+		}
+	}
+}
+
+func validCommentStatus(status string) bool {
+	switch status {
+	case model.CommentStatusPending, model.CommentStatusApproved, model.CommentStatusSpam, model.CommentStatusRejected:
+		return true
+	}
+	return false
+}
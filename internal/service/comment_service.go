@@ -2,11 +2,16 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"log"
+	"time"
 
 	"go-blog/internal/dto"
 	"go-blog/internal/model"
 	"go-blog/internal/repository"
+	"go-blog/internal/spam"
 	"gorm.io/gorm"
 )
 
@@ -16,30 +21,65 @@ var (
 	ErrCommentForbidden = errors.New("comment forbidden")
 	ErrParentMismatch   = errors.New("parent comment mismatch")
 	ErrPostMissing      = errors.New("post not found")
+	ErrInvalidCursor    = errors.New("invalid cursor")
 )
 
 // CommentService 聚合评论相关的业务逻辑。
 type CommentService struct {
 	commentRepo *repository.CommentRepository
 	postRepo    *repository.PostRepository
+	userRepo    *repository.UserRepository // 垃圾检测时查询评论者的用户名与邮箱
+	reactions   *ReactionService
+	spamChecker spam.SpamChecker // 可为 nil；实现 spam.Trainer 时由审核结果训练
+	mentions    *MentionService
+	notifier    *NotificationService
+	streams     *StreamService
+	MaxDepth    int              // 回复最大层级，更深的回复挂到允许的最深祖先下
+	Moderation  ModerationPolicy // 评论审核策略
+	EditWindow  time.Duration    // 作者可编辑评论的时间窗口
 }
 
-// NewCommentService 构造评论服务，注入评论与文章仓库。
-func NewCommentService(commentRepo *repository.CommentRepository, postRepo *repository.PostRepository) *CommentService {
+// NewCommentService 构造评论服务，注入评论、文章与用户仓库、表态服务、垃圾检测器、提及、通知与实时推送服务，并读取最大嵌套层级、审核策略与编辑窗口。
+func NewCommentService(commentRepo *repository.CommentRepository, postRepo *repository.PostRepository, userRepo *repository.UserRepository, reactions *ReactionService, spamChecker spam.SpamChecker, mentions *MentionService, notifier *NotificationService, streams *StreamService) *CommentService {
 	return &CommentService{
 		commentRepo: commentRepo,
 		postRepo:    postRepo,
+		userRepo:    userRepo,
+		reactions:   reactions,
+		spamChecker: spamChecker,
+		mentions:    mentions,
+		notifier:    notifier,
+		streams:     streams,
+		MaxDepth:    model.LoadCommentMaxDepth(),
+		Moderation:  LoadModerationPolicy(),
+		EditWindow:  LoadCommentEditWindow(),
 	}
 }
 
-// CreateComment 创建评论，支持父子关系校验。
-func (s *CommentService) CreateComment(ctx context.Context, uid uint, req dto.CreateCommentReq) (*model.Comment, error) {
-	if _, err := s.postRepo.FindByID(ctx, req.PostId); err != nil {
+// flattenParent 返回回复实际挂载的父评论ID：父评论已达最大层级时，挂到其位于 maxDepth-1 层的祖先下。
+func flattenParent(parent *model.Comment, maxDepth int) uint {
+	if parent.Depth < maxDepth {
+		return parent.Id
+	}
+	if ids := parent.PathIDs(); len(ids) >= maxDepth {
+		return ids[maxDepth-1]
+	}
+	return parent.Id
+}
+
+// CreateComment 创建评论，要求文章对当前用户可读且已发布（作者除外），支持父子关系校验；
+// 按审核策略决定评论是直接发布还是进入待审核队列。
+func (s *CommentService) CreateComment(ctx context.Context, uid uint, role string, req dto.CreateCommentReq) (*model.Comment, error) {
+	post, err := s.postRepo.FindByID(ctx, req.PostId)
+	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrPostMissing
 		}
 		return nil, err
 	}
+	if err := s.authorizeCommenting(ctx, uid, post, req.Password); err != nil {
+		return nil, err
+	}
 
 	if req.ParentId != nil {
 		parent, err := s.commentRepo.FindByID(ctx, *req.ParentId)
@@ -49,25 +89,36 @@ func (s *CommentService) CreateComment(ctx context.Context, uid uint, req dto.Cr
 			}
 			return nil, err
 		}
+		if !parent.VisibleTo(uid) || parent.Tombstone {
+			return nil, ErrCommentNotFound
+		}
 		if parent.PostId != req.PostId {
 			return nil, ErrParentMismatch
 		}
+		parentID := flattenParent(parent, s.MaxDepth)
+		req.ParentId = &parentID
 	}
 
 	comment := &model.Comment{
-		PostId:   req.PostId,
-		UserId:   uid,
-		Content:  req.Content,
-		ParentId: req.ParentId,
+		PostId:    req.PostId,
+		UserId:    uid,
+		Content:   req.Content,
+		ParentId:  req.ParentId,
+		IP:        req.IP,
+		UserAgent: truncate(req.UserAgent, 255),
+	}
+	if comment.Status, err = s.initialStatus(ctx, comment, role, post); err != nil {
+		return nil, err
 	}
 	if err := s.commentRepo.Create(ctx, comment); err != nil {
 		return nil, err
 	}
+	s.commentCreated(ctx, comment, post)
 	return comment, nil
 }
 
-// ReplyToComment 针对父评论创建回复，自动继承文章ID。
-func (s *CommentService) ReplyToComment(ctx context.Context, uid, parentID uint, req dto.ReplyCommentReq) (*model.Comment, error) {
+// ReplyToComment 针对父评论创建回复，自动继承文章ID，审核规则与 CreateComment 相同。
+func (s *CommentService) ReplyToComment(ctx context.Context, uid uint, role string, parentID uint, req dto.ReplyCommentReq) (*model.Comment, error) {
 	parent, err := s.commentRepo.FindByID(ctx, parentID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -75,75 +126,368 @@ func (s *CommentService) ReplyToComment(ctx context.Context, uid, parentID uint,
 		}
 		return nil, err
 	}
-
+	if !parent.VisibleTo(uid) || parent.Tombstone {
+		return nil, ErrCommentNotFound
+	}
+	post, err := s.postRepo.FindByID(ctx, parent.PostId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostMissing
+		}
+		return nil, err
+	}
+	if err := s.authorizeCommenting(ctx, uid, post, req.Password); err != nil {
+		return nil, err
+	}
+	target := flattenParent(parent, s.MaxDepth)
 	comment := &model.Comment{
-		PostId:   parent.PostId,
-		UserId:   uid,
-		Content:  req.Content,
-		ParentId: &parent.Id,
+		PostId:    parent.PostId,
+		UserId:    uid,
+		Content:   req.Content,
+		ParentId:  &target,
+		IP:        req.IP,
+		UserAgent: truncate(req.UserAgent, 255),
+	}
+	if comment.Status, err = s.initialStatus(ctx, comment, role, post); err != nil {
+		return nil, err
 	}
 	if err := s.commentRepo.Create(ctx, comment); err != nil {
 		return nil, err
 	}
+	s.commentCreated(ctx, comment, post)
 	return comment, nil
 }
 
-// DeleteComment 删除评论，仅作者本人可操作。
-func (s *CommentService) DeleteComment(ctx context.Context, uid, id uint) error {
+// DeleteComment 删除评论，仅作者本人可操作：没有回复的移入回收站；有回复的变为 "[deleted]" 占位以保持线程完整，
+// 待其回复全部删除后自动永久删除。返回是否为占位删除。
+func (s *CommentService) DeleteComment(ctx context.Context, uid, id uint) (bool, error) {
 	comment, err := s.commentRepo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return ErrCommentNotFound
+			return false, ErrCommentNotFound
 		}
-		return err
+		return false, err
+	}
+	if comment.Tombstone {
+		return false, ErrCommentNotFound
 	}
 	if comment.UserId != uid {
-		return ErrCommentForbidden
+		return false, ErrCommentForbidden
+	}
+	tombstoned, err := s.commentRepo.Delete(ctx, comment)
+	if err != nil {
+		return false, err
+	}
+	if comment.Status == model.CommentStatusApproved {
+		s.broadcastDeleted(ctx, comment)
 	}
-	return s.commentRepo.Delete(ctx, comment)
+	return tombstoned, nil
 }
 
-// ListCommentsByPost 根据文章构建评论树，并返回总数。
-func (s *CommentService) ListCommentsByPost(ctx context.Context, postID uint) ([]dto.CommentResp, int64, error) {
-	comments, err := s.commentRepo.ListByPostID(ctx, postID)
+// ListCommentsByPost 分页返回文章的顶层评论，每条附带最早的 q.Replies 条直接回复、回复总数与加载更多的游标；
+// 与文章详情遵循相同的可见性规则，只返回已通过审核的评论及当前用户自己的评论。
+func (s *CommentService) ListCommentsByPost(ctx context.Context, uid, postID uint, password string, q dto.CommentListQuery) (*dto.CommentPageResp, error) {
+	if err := s.authorizePost(ctx, uid, postID, password); err != nil {
+		return nil, err
+	}
+	roots, total, err := s.commentRepo.ListRootsByPost(ctx, postID, uid, q.Page, q.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	count, err := s.commentRepo.CountByPost(ctx, postID, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	rootIDs := commentIDs(roots)
+	replies, err := s.commentRepo.ListRepliesPreview(ctx, rootIDs, uid, q.Replies)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+	all := append(append([]model.Comment{}, roots...), replies...)
+	resps, err := s.toCommentResps(ctx, uid, all)
+	if err != nil {
+		return nil, err
+	}
+
+	byParent := make(map[uint][]dto.CommentResp)
+	for _, r := range resps[len(roots):] {
+		byParent[*r.ParentId] = append(byParent[*r.ParentId], r)
+	}
+	list := resps[:len(roots)]
+	for i := range list {
+		preview := byParent[list[i].Id]
+		list[i].Replies = preview
+		if n := len(preview); int64(n) < list[i].ReplyCount {
+			list[i].NextCursor = encodeCommentCursor(preview, list[i].Id)
+		}
 	}
-	resp := buildCommentTree(comments)
-	return resp, int64(len(comments)), nil
+	return &dto.CommentPageResp{
+		Page:         q.Page,
+		PageSize:     q.PageSize,
+		Total:        total,
+		CommentCount: count,
+		List:         list,
+	}, nil
 }
 
-func buildCommentTree(list []model.Comment) []dto.CommentResp {
-	m := make(map[uint]*dto.CommentResp, len(list))
-	for _, c := range list {
-		m[c.Id] = &dto.CommentResp{
-			Id:       c.Id,
-			Content:  c.Content,
-			User:     dto.UserBrief{Id: c.User.ID, Username: c.User.Username},
-			ParentId: c.ParentId,
-			PostId:   c.PostId,
-			Replies:  []dto.CommentResp{},
+// ListReplies 按游标加载某评论的直接回复（"加载更多回复"）；cursor 为空时从头开始。
+func (s *CommentService) ListReplies(ctx context.Context, uid, commentID uint, password, cursor string, limit int) (*dto.CommentRepliesResp, error) {
+	parent, err := s.commentRepo.FindByID(ctx, commentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
 		}
+		return nil, err
+	}
+	if !parent.VisibleTo(uid) {
+		return nil, ErrCommentNotFound
+	}
+	if err := s.authorizePost(ctx, uid, parent.PostId, password); err != nil {
+		return nil, err
+	}
+	afterID, err := decodeCommentCursor(cursor, commentID)
+	if err != nil {
+		return nil, err
 	}
 
-	for i := len(list) - 1; i >= 0; i-- {
-		c := list[i]
-		if c.ParentId != nil && *c.ParentId != c.Id {
-			if parent, ok := m[*c.ParentId]; ok {
-				parent.Replies = append(parent.Replies, *m[c.Id])
-			}
+	// 多取一条用于判断是否还有更多
+	replies, err := s.commentRepo.ListReplies(ctx, commentID, uid, afterID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	hasMore := len(replies) > limit
+	if hasMore {
+		replies = replies[:limit]
+	}
+	list, err := s.toCommentResps(ctx, uid, replies)
+	if err != nil {
+		return nil, err
+	}
+	resp := &dto.CommentRepliesResp{List: list, HasMore: hasMore}
+	if hasMore {
+		resp.NextCursor = encodeCommentCursor(list, commentID)
+	}
+	return resp, nil
+}
+
+// GetThread 返回一条评论及其全部后代组成的树（一次按物化路径查询）。
+func (s *CommentService) GetThread(ctx context.Context, uid, id uint, password string) (*dto.CommentResp, error) {
+	comment, err := s.commentRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
 		}
+		return nil, err
+	}
+	if !comment.VisibleTo(uid) {
+		return nil, ErrCommentNotFound
+	}
+	if err := s.authorizePost(ctx, uid, comment.PostId, password); err != nil {
+		return nil, err
+	}
+	subtree, err := s.commentRepo.ListSubtree(ctx, comment, uid)
+	if err != nil {
+		return nil, err
+	}
+	resps, err := s.toCommentResps(ctx, uid, subtree)
+	if err != nil {
+		return nil, err
 	}
 
-	var roots []dto.CommentResp
+	// 先序排列：每个节点的父节点都在它之前，倒序挂载即可保持子节点顺序
+	nodes := make(map[uint]*dto.CommentResp, len(resps))
+	for i := range resps {
+		nodes[resps[i].Id] = &resps[i]
+	}
+	for i := len(resps) - 1; i > 0; i-- {
+		r := resps[i]
+		if r.ParentId == nil {
+			continue
+		}
+		if parent, ok := nodes[*r.ParentId]; ok {
+			parent.Replies = append([]dto.CommentResp{*nodes[r.Id]}, parent.Replies...)
+		}
+	}
+	return nodes[comment.Id], nil
+}
+
+// authorizePost 校验文章存在且对当前用户可读，不可读时统一返回 ErrPostMissing（密码错误除外）。
+func (s *CommentService) authorizePost(ctx context.Context, uid, postID uint, password string) error {
+	post, err := s.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPostMissing
+		}
+		return err
+	}
+	if err := authorizePostRead(ctx, s.postRepo, post, uid, password); err != nil {
+		if errors.Is(err, ErrPostNotFound) {
+			return ErrPostMissing
+		}
+		return err
+	}
+	return nil
+}
+
+// authorizeCommenting 校验当前用户能否在文章下发表评论（规则同 authorizePostInteract），不可见时返回 ErrPostMissing。
+func (s *CommentService) authorizeCommenting(ctx context.Context, uid uint, post *model.Post, password string) error {
+	if err := authorizePostInteract(ctx, s.postRepo, post, uid, password); err != nil {
+		if errors.Is(err, ErrPostNotFound) {
+			return ErrPostMissing
+		}
+		return err
+	}
+	return nil
+}
+
+// toCommentResps 转换评论并填充表态与直接回复数。
+func (s *CommentService) toCommentResps(ctx context.Context, uid uint, list []model.Comment) ([]dto.CommentResp, error) {
+	ids := commentIDs(list)
+	counts, mine, err := s.reactions.CommentReactions(ctx, uid, ids)
+	if err != nil {
+		return nil, err
+	}
+	var mentions map[uint][]model.MentionRef
+	if s.mentions != nil {
+		if mentions, err = s.mentions.Refs(ctx, model.MentionTargetComment, ids); err != nil {
+			return nil, err
+		}
+	}
+	replyCounts, err := s.commentRepo.CountReplies(ctx, ids, uid)
+	if err != nil {
+		return nil, err
+	}
+	resps := make([]dto.CommentResp, 0, len(list))
 	for _, c := range list {
-		if c.ParentId == nil || *c.ParentId == c.Id {
-			roots = append(roots, *m[c.Id])
+		if c.Tombstone {
+			resps = append(resps, dto.CommentResp{
+				Id:         c.Id,
+				Content:    model.DeletedCommentContent,
+				ParentId:   c.ParentId,
+				PostId:     c.PostId,
+				Status:     c.Status,
+				Deleted:    true,
+				Depth:      c.Depth,
+				ReplyCount: replyCounts[c.Id],
+			})
 			continue
 		}
-		if _, ok := m[*c.ParentId]; !ok {
-			roots = append(roots, *m[c.Id])
+		resps = append(resps, dto.CommentResp{
+			Id:          c.Id,
+			Content:     c.Content,
+			User:        dto.UserBrief{Id: c.User.ID, Username: c.User.Username},
+			ParentId:    c.ParentId,
+			PostId:      c.PostId,
+			Status:      c.Status,
+			Edited:      c.EditedAt != nil,
+			EditedAt:    c.EditedAt,
+			Depth:       c.Depth,
+			ReplyCount:  replyCounts[c.Id],
+			Reactions:   counts[c.Id],
+			MyReactions: mine[c.Id],
+			Mentions:    mentions[c.Id],
+		})
+	}
+	return resps, nil
+}
+
+// commentCreated 新评论保存后更新提及；直接通过审核的评论通知被回复者与文章所有者（待审核的在审核通过时通知）。
+func (s *CommentService) commentCreated(ctx context.Context, comment *model.Comment, post *model.Post) {
+	s.syncMentions(ctx, comment, post)
+	if comment.Status == model.CommentStatusApproved {
+		s.notifier.CommentPublished(ctx, comment, post)
+		s.broadcast(ctx, StreamCommentCreated, comment.Id)
+	}
+}
+
+// broadcast 向评论所在文章的实时流推送评论（按匿名视角转换，只推送已通过审核且未删除的评论）。
+func (s *CommentService) broadcast(ctx context.Context, typ string, ids ...uint) {
+	if s.streams == nil || len(ids) == 0 {
+		return
+	}
+	comments, err := s.commentRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		log.Printf("broadcast comments error: %v", err)
+		return
+	}
+	visible := comments[:0]
+	for _, c := range comments {
+		if c.Status == model.CommentStatusApproved && !c.Tombstone {
+			visible = append(visible, c)
 		}
 	}
-	return roots
+	resps, err := s.toCommentResps(ctx, 0, visible)
+	if err != nil {
+		log.Printf("broadcast comments error: %v", err)
+		return
+	}
+	for _, r := range resps {
+		s.streams.Publish(ctx, PostTopic(r.PostId), typ, r)
+	}
+}
+
+// broadcastDeleted 通知文章的实时流评论已删除或不再公开；Tombstone 为 true 时客户端应显示为占位。
+func (s *CommentService) broadcastDeleted(ctx context.Context, comment *model.Comment) {
+	if s.streams == nil {
+		return
+	}
+	s.streams.Publish(ctx, PostTopic(comment.PostId), StreamCommentDeleted, dto.CommentDeletedEvent{
+		Id:        comment.Id,
+		PostId:    comment.PostId,
+		Tombstone: comment.Tombstone,
+	})
+}
+
+// AuthorizeStream 校验当前用户可以订阅文章的评论流（与评论列表的可见性规则相同）。
+func (s *CommentService) AuthorizeStream(ctx context.Context, uid, postID uint, password string) error {
+	return s.authorizePost(ctx, uid, postID, password)
+}
+
+// syncMentions 按评论内容更新提及记录；已通过审核且所在文章可通知时通知被提及者。
+// 评论已保存成功，提及处理失败只记录日志。
+func (s *CommentService) syncMentions(ctx context.Context, comment *model.Comment, post *model.Post) {
+	if s.mentions == nil {
+		return
+	}
+	public := comment.Status == model.CommentStatusApproved && mentionsPublic(post)
+	refs, err := s.mentions.Sync(ctx, model.MentionTargetComment, comment.Id, comment.UserId, comment.Content, public)
+	if err != nil {
+		log.Printf("sync mentions for comment %d error: %v", comment.Id, err)
+		return
+	}
+	comment.Mentions = refs
+}
+
+func commentIDs(list []model.Comment) []uint {
+	ids := make([]uint, 0, len(list))
+	for _, c := range list {
+		ids = append(ids, c.Id)
+	}
+	return ids
+}
+
+// encodeCommentCursor 以已返回的最后一条回复生成不透明游标；尚未返回任何回复时从头开始。
+func encodeCommentCursor(returned []dto.CommentResp, parentID uint) string {
+	var last uint
+	if n := len(returned); n > 0 {
+		last = returned[n-1].Id
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", parentID, last)))
+}
+
+// decodeCommentCursor 解析游标并校验其属于 parentID，返回应从其后继续的评论ID。
+func decodeCommentCursor(cursor string, parentID uint) (uint, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	var owner, afterID uint
+	if _, err := fmt.Sscanf(string(raw), "%d:%d", &owner, &afterID); err != nil || owner != parentID {
+		return 0, ErrInvalidCursor
+	}
+	return afterID, nil
 }
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"go-blog/internal/dto"
+	"go-blog/internal/model"
+)
+
+// pathOf 按从根到自身的ID构造评论的物化路径与层级。
+func pathOf(ids ...uint) *model.Comment {
+	var b strings.Builder
+	for _, id := range ids {
+		b.WriteString(model.CommentPathSegment(id))
+	}
+	return &model.Comment{Id: ids[len(ids)-1], Path: b.String(), Depth: len(ids) - 1}
+}
+
+func TestFlattenParent(t *testing.T) {
+	tests := []struct {
+		name     string
+		parent   *model.Comment
+		maxDepth int
+		want     uint
+	}{
+		{"top level", pathOf(1), 5, 1},
+		{"below max", pathOf(1, 2, 3), 5, 3},
+		{"one above max", pathOf(1, 2, 3, 4, 5), 5, 5},
+		{"at max", pathOf(1, 2, 3, 4, 5, 6), 5, 5},
+		{"max depth 1", pathOf(1, 2), 1, 1},
+		{"max depth 1 top level", pathOf(1), 1, 1},
+		{"deeper than max after config change", pathOf(1, 2, 3, 4), 2, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := flattenParent(tt.parent, tt.maxDepth); got != tt.want {
+				t.Errorf("flattenParent = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlattenParentKeepsDepthBounded(t *testing.T) {
+	const maxDepth = 3
+	// 模拟不断回复最新一条评论：挂载后的层级始终不超过 maxDepth
+	chain := []uint{1}
+	for id := uint(2); id < 20; id++ {
+		parent := pathOf(chain...)
+		target := flattenParent(parent, maxDepth)
+		var ancestors []uint
+		for _, a := range chain {
+			ancestors = append(ancestors, a)
+			if a == target {
+				break
+			}
+		}
+		chain = append(ancestors, id)
+		if depth := len(chain) - 1; depth > maxDepth {
+			t.Fatalf("reply %d at depth %d, max %d", id, depth, maxDepth)
+		}
+	}
+}
+
+func TestCommentCursorRoundTrip(t *testing.T) {
+	returned := []dto.CommentResp{{Id: 3}, {Id: 8}, {Id: 11}}
+	cursor := encodeCommentCursor(returned, 7)
+	after, err := decodeCommentCursor(cursor, 7)
+	if err != nil || after != 11 {
+		t.Fatalf("decode = %d, %v; want 11", after, err)
+	}
+
+	empty := encodeCommentCursor(nil, 7)
+	if after, err := decodeCommentCursor(empty, 7); err != nil || after != 0 {
+		t.Fatalf("decode empty = %d, %v; want 0", after, err)
+	}
+	if after, err := decodeCommentCursor("", 7); err != nil || after != 0 {
+		t.Fatalf("decode blank = %d, %v; want 0", after, err)
+	}
+}
+
+func TestDecodeCommentCursorInvalid(t *testing.T) {
+	valid := encodeCommentCursor([]dto.CommentResp{{Id: 5}}, 7)
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"other parent", valid},
+		{"not base64", "!!!"},
+		{"garbage", base64.RawURLEncoding.EncodeToString([]byte("hello"))},
+		{"missing separator", base64.RawURLEncoding.EncodeToString([]byte("95"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeCommentCursor(tt.cursor, 9); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("decode(%q) err = %v, want ErrInvalidCursor", tt.cursor, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+
+	"go-blog/internal/repository"
+	"go-blog/internal/util"
+)
+
+// HotWeights 热度算法参数：各项互动的权重与时间衰减指数。
+type HotWeights struct {
+	View    float64
+	Comment float64
+	Like    float64
+	Gravity float64
+}
+
+// LoadHotWeights 从环境变量读取热度参数：
+// HOT_WEIGHT_VIEW（默认 1）、HOT_WEIGHT_COMMENT（默认 5）、HOT_WEIGHT_LIKE（默认 3）、HOT_GRAVITY（默认 1.8）。
+func LoadHotWeights() HotWeights {
+	return HotWeights{
+		View:    util.EnvFloat("HOT_WEIGHT_VIEW", 1),
+		Comment: util.EnvFloat("HOT_WEIGHT_COMMENT", 5),
+		Like:    util.EnvFloat("HOT_WEIGHT_LIKE", 3),
+		Gravity: util.EnvFloat("HOT_GRAVITY", 1.8),
+	}
+}
+
+// HotScore 按 HN 风格计算热度：互动加权和 / (发布小时数 + 2)^gravity。
+func HotScore(w HotWeights, in repository.HotScoreInput, now time.Time) float64 {
+	points := w.View*float64(in.ViewCount) + w.Comment*float64(in.CommentCount) + w.Like*float64(in.LikeCount)
+	ageHours := now.Sub(in.CreatedAt).Hours()
+	if ageHours < 0 {
+		ageHours = 0
+	}
+	return points / math.Pow(ageHours+2, w.Gravity)
+}
+
+// HotRanker 定期重算已发布文章的热度分并写入 posts.hot_score。
+type HotRanker struct {
+	repo     *repository.PostRepository
+	weights  HotWeights
+	interval time.Duration
+}
+
+// NewHotRanker 构造热度重算任务，间隔由 HOT_RECOMPUTE_INTERVAL（秒，默认 300）配置。
+func NewHotRanker(repo *repository.PostRepository) *HotRanker {
+	return &HotRanker{
+		repo:     repo,
+		weights:  LoadHotWeights(),
+		interval: util.EnvSeconds("HOT_RECOMPUTE_INTERVAL", 300),
+	}
+}
+
+// Recompute 重新计算全部已发布文章的热度分。
+func (h *HotRanker) Recompute(ctx context.Context) error {
+	inputs, err := h.repo.ListHotScoreInputs(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	scores := make(map[uint]float64, len(inputs))
+	for _, in := range inputs {
+		scores[in.PostID] = HotScore(h.weights, in, now)
+	}
+	return h.repo.UpdateHotScores(ctx, scores)
+}
+
+// Run 启动后立即计算一次，之后按固定间隔重算，直到 ctx 结束。
+func (h *HotRanker) Run(ctx context.Context) {
+	if err := h.Recompute(ctx); err != nil {
+		log.Printf("recompute hot scores error: %v", err)
+	}
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := h.Recompute(ctx); err != nil {
+				log.Printf("recompute hot scores error: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"go-blog/internal/dto"
+	"go-blog/internal/importer"
+	"go-blog/internal/model"
+	"go-blog/internal/util"
+	"gorm.io/gorm"
+)
+
+// ImportDisqus 导入 Disqus 评论导出文件，按讨论串匹配本地文章并保留回复层级。
+// 讨论串依次按以下方式匹配：disqus_identifier 中的 WordPress 文章 ID（需先导入 WordPress）、
+// 链接末段或 identifier 对应的文章 slug、指向本站（SITE_BASE_URL）/posts/<id> 的链接。
+func (s *ImportService) ImportDisqus(ctx context.Context, r io.Reader, opts ImportOptions) (*dto.ImportReport, error) {
+	export, err := importer.ParseDisqus(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+	st := newImportState(opts.DryRun)
+	st.report.Comments = &dto.ImportCommentStats{}
+
+	threads := make(map[string]uint, len(export.Threads))
+	for _, t := range export.Threads {
+		postID, err := s.resolveThread(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		threads[t.ID] = postID
+	}
+
+	unmatched := make(map[string]bool)
+	comments := make([]extComment, 0, len(export.Posts))
+	for _, p := range export.Posts {
+		postID, ok := threads[p.ThreadID]
+		if !ok || postID == 0 {
+			unmatched[p.ThreadID] = true
+		}
+		comments = append(comments, extComment{
+			ID:        p.ID,
+			Parent:    p.Parent,
+			PostID:    postID,
+			UserKey:   commenterKey(p.AuthorEmail, p.AuthorUser, p.AuthorName),
+			Author:    p.AuthorName,
+			Email:     p.AuthorEmail,
+			Content:   p.Message,
+			CreatedAt: p.CreatedAt,
+			Skip:      postID == 0 || p.IsDeleted || p.IsSpam || p.Message == "",
+		})
+	}
+	for _, t := range export.Threads {
+		if unmatched[t.ID] {
+			st.report.Warnings = append(st.report.Warnings, fmt.Sprintf("thread %s (%s) matches no post, its comments are skipped", t.ID, t.Link))
+		}
+	}
+
+	s.importComments(ctx, model.ImportSourceDisqus, comments, opts.DryRun, st)
+	return s.finish(st), nil
+}
+
+// resolveThread 查找讨论串对应的本地文章，找不到时返回 0。
+func (s *ImportService) resolveThread(ctx context.Context, t importer.DisqusThread) (uint, error) {
+	// 1. WordPress 插件生成的 identifier 形如 "123 https://example.com/?p=123"
+	if fields := strings.Fields(t.Identifier); len(fields) > 0 {
+		if _, err := strconv.ParseUint(fields[0], 10, 64); err == nil {
+			id, err := s.importRepo.LocalID(ctx, model.ImportSourceWordPress, model.ImportKindPost, fields[0])
+			if err != nil || id != 0 {
+				return id, err
+			}
+		}
+	}
+
+	// 2. 按 slug 匹配
+	last := lastPathSegment(t.Link)
+	for _, slug := range []string{last, t.Identifier} {
+		if slug == "" {
+			continue
+		}
+		post, err := s.postRepo.FindBySlug(ctx, slug)
+		if err == nil {
+			return post.ID, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, err
+		}
+	}
+
+	// 3. 本站链接形如 <SITE_BASE_URL>/posts/42
+	if id := sitePostID(t.Link); id > 0 {
+		post, err := s.postRepo.FindByID(ctx, id)
+		if err == nil {
+			return post.ID, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, err
+		}
+	}
+	return 0, nil
+}
+
+// lastPathSegment 返回链接路径的最后一段（去掉 .html 等扩展名）。
+func lastPathSegment(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	p := strings.Trim(u.Path, "/")
+	if p == "" {
+		return ""
+	}
+	base := path.Base(p)
+	if unescaped, err := url.PathUnescape(base); err == nil {
+		base = unescaped
+	}
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+// sitePostID 解析本站文章链接 <SITE_BASE_URL>/posts/<id>（允许末尾斜杠）；主机或路径不符、
+// 未配置 SITE_BASE_URL 时返回 0，避免把旧站 /2019/05/、/archives/12 之类的链接误配到本地文章。
+func sitePostID(link string) uint {
+	base, err := url.Parse(strings.TrimRight(util.EnvString("SITE_BASE_URL", ""), "/"))
+	if err != nil || base.Host == "" {
+		return 0
+	}
+	u, err := url.Parse(link)
+	if err != nil || !strings.EqualFold(u.Host, base.Host) {
+		return 0
+	}
+	rest, ok := strings.CutPrefix(u.Path, base.Path+"/posts/")
+	if !ok {
+		return 0
+	}
+	id, err := strconv.ParseUint(strings.TrimSuffix(rest, "/"), 10, 64)
+	if err != nil || id == 0 {
+		return 0
+	}
+	return uint(id)
+}
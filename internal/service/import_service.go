@@ -0,0 +1,665 @@
+package service
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"go-blog/internal/dto"
+	"go-blog/internal/importer"
+	"go-blog/internal/model"
+	"go-blog/internal/repository"
+	"go-blog/internal/util"
+	"gorm.io/gorm"
+)
+
+// ImportService 负责从外部博客批量导入文章（Markdown 目录/ZIP 等）。
+type ImportService struct {
+	db           *gorm.DB
+	userRepo     *repository.UserRepository
+	postRepo     *repository.PostRepository
+	categoryRepo *repository.CategoryRepository
+	tagRepo      *repository.TagRepository
+	commentRepo  *repository.CommentRepository
+	importRepo   *repository.ImportRepository
+	uploads      *UploadService
+	related      *RelatedService
+	maxDepth     int
+}
+
+// NewImportService 构造导入服务。
+func NewImportService(db *gorm.DB, userRepo *repository.UserRepository, postRepo *repository.PostRepository, categoryRepo *repository.CategoryRepository, tagRepo *repository.TagRepository, commentRepo *repository.CommentRepository, importRepo *repository.ImportRepository, uploads *UploadService, related *RelatedService) *ImportService {
+	return &ImportService{
+		db:           db,
+		userRepo:     userRepo,
+		postRepo:     postRepo,
+		categoryRepo: categoryRepo,
+		tagRepo:      tagRepo,
+		commentRepo:  commentRepo,
+		importRepo:   importRepo,
+		uploads:      uploads,
+		related:      related,
+		maxDepth:     model.LoadCommentMaxDepth(),
+	}
+}
+
+// ImportOptions 导入参数。
+type ImportOptions struct {
+	UserID          uint   // 导入文章的所有者（WordPress 导入中作为未知作者的兜底）
+	DryRun          bool   // 只生成报告，不写库、不上传图片
+	DefaultCategory string // front matter 未指定分类时使用的分类名
+}
+
+// importState 一次导入过程中已解析的分类、标签与用户（按名称/键缓存，dry-run 时新建项 ID 为 0）。
+type importState struct {
+	report     *dto.ImportReport
+	categories map[string]uint
+	tags       map[string]uint
+	users      map[string]uint
+}
+
+// importRef 外部记录的来源标识，新建本地记录时一并写入 import_records。
+type importRef struct {
+	source, kind, id string
+}
+
+// newImportState 初始化导入状态与空报告。
+func newImportState(dryRun bool) *importState {
+	return &importState{
+		report: &dto.ImportReport{
+			DryRun:        dryRun,
+			NewCategories: []string{},
+			NewTags:       []string{},
+			Posts:         []dto.ImportPostResult{},
+		},
+		categories: make(map[string]uint),
+		tags:       make(map[string]uint),
+		users:      make(map[string]uint),
+	}
+}
+
+// finish 汇总文章计数，并在有写入时清空相关推荐缓存。
+func (s *ImportService) finish(st *importState) *dto.ImportReport {
+	for _, res := range st.report.Posts {
+		switch res.Action {
+		case dto.ImportActionCreate:
+			st.report.Created++
+		case dto.ImportActionUpdate:
+			st.report.Updated++
+		default:
+			st.report.Failed++
+		}
+	}
+	st.report.Total = len(st.report.Posts)
+
+	if !st.report.DryRun && st.report.Created+st.report.Updated > 0 && s.related != nil {
+		s.related.Invalidate()
+	}
+	return st.report
+}
+
+// checkOwner 确认兜底所有者存在。
+func (s *ImportService) checkOwner(ctx context.Context, uid uint) error {
+	if _, err := s.userRepo.FindByID(ctx, uid); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// ImportMarkdown 导入 Hugo 风格的 Markdown 文章：按需创建分类与标签，图片经 UploadService 重新上传。
+// 已存在相同 slug 的文章会被更新而不是重复创建，因此可以多次导入同一源。
+func (s *ImportService) ImportMarkdown(ctx context.Context, fsys fs.FS, opts ImportOptions) (*dto.ImportReport, error) {
+	if err := s.checkOwner(ctx, opts.UserID); err != nil {
+		return nil, err
+	}
+	opts.applyDefaults()
+
+	posts, failed, err := importer.ParseMarkdownFS(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	st := newImportState(opts.DryRun)
+
+	// 1. 解析失败的文件直接记为错误
+	paths := make([]string, 0, len(failed))
+	for p := range failed {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		st.report.Posts = append(st.report.Posts, dto.ImportPostResult{
+			Path:   p,
+			Action: dto.ImportActionError,
+			Error:  failed[p].Error(),
+		})
+	}
+
+	// 2. 逐篇导入，单篇失败不影响其他文章
+	for i := range posts {
+		res := s.importPost(ctx, fsys, &posts[i], opts, st)
+		st.report.Posts = append(st.report.Posts, res)
+	}
+	return s.finish(st), nil
+}
+
+// importPost 导入单篇文章并返回结果。
+func (s *ImportService) importPost(ctx context.Context, fsys fs.FS, mp *importer.MarkdownPost, opts ImportOptions, st *importState) dto.ImportPostResult {
+	res := dto.ImportPostResult{Path: mp.Path, Slug: mp.Slug, Title: mp.Title}
+	fail := func(err error) dto.ImportPostResult {
+		res.Action = dto.ImportActionError
+		res.Error = err.Error()
+		return res
+	}
+
+	// 1. 分类：仓库只支持单分类，取第一个
+	categoryName := opts.DefaultCategory
+	if len(mp.Categories) > 0 {
+		categoryName = mp.Categories[0]
+		if len(mp.Categories) > 1 {
+			res.Warnings = append(res.Warnings, fmt.Sprintf("only the first category is kept, ignored: %s", strings.Join(mp.Categories[1:], ", ")))
+		}
+	}
+	categoryID, err := s.resolveCategory(ctx, categoryName, "", nil, opts.DryRun, st)
+	if err != nil {
+		return fail(err)
+	}
+
+	// 2. 标签
+	tagIDs := make([]uint, 0, len(mp.Tags))
+	for _, name := range mp.Tags {
+		id, err := s.resolveTag(ctx, name, "", opts.DryRun, st)
+		if err != nil {
+			return fail(err)
+		}
+		if id != 0 {
+			tagIDs = append(tagIDs, id)
+		}
+	}
+
+	// 3. 图片：重新上传并改写正文中的引用
+	content := mp.Body
+	for _, ref := range importer.ImageRefs(mp.Body) {
+		img := s.importImage(ctx, fsys, mp.Path, ref, opts.DryRun)
+		if img.Error != "" {
+			res.Warnings = append(res.Warnings, fmt.Sprintf("image %s: %s", ref, img.Error))
+		} else {
+			st.report.Images++
+			if img.URL != "" {
+				content = strings.ReplaceAll(content, "("+ref, "("+img.URL)
+			}
+		}
+		res.Images = append(res.Images, img)
+	}
+
+	status := model.PostStatusPublished
+	if mp.Draft {
+		status = model.PostStatusDraft
+	}
+	res.Status = status
+
+	// 4. 按 slug 判断新建还是更新
+	existing, err := s.postRepo.FindBySlug(ctx, mp.Slug)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fail(err)
+	}
+	if existing != nil && existing.UserID != opts.UserID {
+		return fail(fmt.Errorf("slug %q is already used by post %d of another user", mp.Slug, existing.ID))
+	}
+
+	if existing == nil {
+		res.Action = dto.ImportActionCreate
+		if opts.DryRun {
+			return res
+		}
+		post := &model.Post{
+			Title:      mp.Title,
+			Content:    content,
+			Slug:       mp.Slug,
+			CategoryId: categoryID,
+			Status:     status,
+			Visibility: model.PostVisibilityPublic,
+			UserID:     opts.UserID,
+			Version:    1,
+		}
+		if !mp.Date.IsZero() {
+			post.CreatedAt = mp.Date
+		}
+		if err := s.createPost(ctx, post, tagIDs, nil); err != nil {
+			return fail(err)
+		}
+		res.PostId = post.ID
+		return res
+	}
+
+	res.Action = dto.ImportActionUpdate
+	res.PostId = existing.ID
+	if opts.DryRun {
+		return res
+	}
+	if err := s.updatePost(ctx, existing, mp.Title, content, categoryID, status, tagIDs, opts.UserID); err != nil {
+		return fail(err)
+	}
+	return res
+}
+
+// createPost 在事务中写入文章、所有者、标签与初始状态记录；ref 非空时同时登记导入记录。
+func (s *ImportService) createPost(ctx context.Context, post *model.Post, tagIDs []uint, ref *importRef) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		repoTx := s.postRepo.WithDB(tx)
+		if err := repoTx.Create(ctx, post); err != nil {
+			return err
+		}
+		if err := repoTx.AddAuthor(ctx, &model.PostAuthor{
+			PostId: post.ID,
+			UserId: post.UserID,
+			Role:   model.PostAuthorOwner,
+		}); err != nil {
+			return err
+		}
+		if len(tagIDs) > 0 {
+			if err := repoTx.ReplaceTags(ctx, post, tagIDs); err != nil {
+				return err
+			}
+		}
+		if err := repoTx.AddTransition(ctx, &model.PostTransition{
+			PostId:   post.ID,
+			UserId:   post.UserID,
+			ToStatus: post.Status,
+			Comment:  "imported",
+		}); err != nil {
+			return err
+		}
+		if ref == nil {
+			return nil
+		}
+		return s.importRepo.WithDB(tx).Save(ctx, ref.source, ref.kind, ref.id, post.ID)
+	})
+}
+
+// updatePost 在事务中以乐观锁覆盖已有文章的内容、分类、状态与标签。
+func (s *ImportService) updatePost(ctx context.Context, post *model.Post, title, content string, categoryID uint, status string, tagIDs []uint, uid uint) error {
+	from := post.Status
+	post.Title = title
+	post.Content = content
+	post.CategoryId = categoryID
+	post.Status = status
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		repoTx := s.postRepo.WithDB(tx)
+		ok, current, err := repoTx.UpdateIfVersion(ctx, post, post.Version)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return &PostConflictError{Current: current}
+		}
+		if err := repoTx.ReplaceTags(ctx, post, tagIDs); err != nil {
+			return err
+		}
+		if from == status {
+			return nil
+		}
+		return repoTx.AddTransition(ctx, &model.PostTransition{
+			PostId:     post.ID,
+			UserId:     uid,
+			FromStatus: from,
+			ToStatus:   status,
+			Comment:    "imported",
+		})
+	})
+}
+
+// importImage 读取导入源中的图片并重新上传；dry-run 时只做校验。
+func (s *ImportService) importImage(ctx context.Context, fsys fs.FS, postPath, ref string, dryRun bool) dto.ImportImageResult {
+	img := dto.ImportImageResult{Ref: ref}
+	src, ok := importer.ResolveImage(fsys, postPath, ref)
+	if !ok {
+		img.Error = "file not found in import source"
+		return img
+	}
+	data, err := fs.ReadFile(fsys, src)
+	if err != nil {
+		img.Error = err.Error()
+		return img
+	}
+	if dryRun {
+		if err := util.ValidateImageBytes(path.Base(src), data, maxUploadSize); err != nil {
+			img.Error = err.Error()
+		}
+		return img
+	}
+	url, err := s.uploads.UploadBytes(ctx, path.Base(src), data)
+	if err != nil {
+		img.Error = err.Error()
+		return img
+	}
+	img.URL = url
+	return img
+}
+
+// resolveCategory 按名称或 slug 查找分类，不存在时创建（dry-run 只记录）；slug 为空时由名称生成。
+func (s *ImportService) resolveCategory(ctx context.Context, name, slug string, parentID *uint, dryRun bool, st *importState) (uint, error) {
+	if id, ok := st.categories[name]; ok {
+		return id, nil
+	}
+	slug = importSlug(name, slug)
+	category, err := s.categoryRepo.FindByNameOrSlug(ctx, name, slug)
+	switch {
+	case err == nil:
+		st.categories[name] = category.Id
+		return category.Id, nil
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return 0, err
+	}
+
+	st.report.NewCategories = append(st.report.NewCategories, name)
+	if dryRun {
+		st.categories[name] = 0
+		return 0, nil
+	}
+	category = &model.Category{Name: name, Slug: slug, ParentId: parentID}
+	if err := s.categoryRepo.Create(ctx, category); err != nil {
+		return 0, fmt.Errorf("create category %q: %w", name, err)
+	}
+	st.categories[name] = category.Id
+	return category.Id, nil
+}
+
+// resolveTag 按名称或 slug 查找标签，不存在时创建（dry-run 只记录）；slug 为空时由名称生成。
+func (s *ImportService) resolveTag(ctx context.Context, name, slug string, dryRun bool, st *importState) (uint, error) {
+	if id, ok := st.tags[name]; ok {
+		return id, nil
+	}
+	slug = importSlug(name, slug)
+	tag, err := s.tagRepo.FindByNameOrSlug(ctx, name, slug)
+	switch {
+	case err == nil:
+		st.tags[name] = tag.Id
+		return tag.Id, nil
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return 0, err
+	}
+
+	st.report.NewTags = append(st.report.NewTags, name)
+	if dryRun {
+		st.tags[name] = 0
+		return 0, nil
+	}
+	tag = &model.Tag{Name: name, Slug: slug}
+	if err := s.tagRepo.Create(ctx, tag); err != nil {
+		return 0, fmt.Errorf("create tag %q: %w", name, err)
+	}
+	st.tags[name] = tag.Id
+	return tag.Id, nil
+}
+
+// applyDefaults 补齐未指定的导入参数。
+func (o *ImportOptions) applyDefaults() {
+	if o.DefaultCategory == "" {
+		o.DefaultCategory = util.EnvString("IMPORT_DEFAULT_CATEGORY", "uncategorized")
+	}
+}
+
+// importSlug 优先使用来源提供的 slug，否则由名称生成。
+func importSlug(name, slug string) string {
+	if slug = util.Slugify(slug); slug != "" {
+		return slug
+	}
+	if slug = util.Slugify(name); slug != "" {
+		return slug
+	}
+	return name
+}
+
+// ErrInvalidImportFile 导入文件无法解析。
+var ErrInvalidImportFile = errors.New("invalid import file")
+
+// importedPassword 导入生成的占位用户的密码哈希：不是合法的 bcrypt 值，因此无法用密码登录。
+const importedPassword = "!imported"
+
+// resolveUser 将外部作者/评论者映射为本地用户：先查导入记录，再按邮箱匹配已有用户，
+// 都没有时创建无法登录的占位用户（dry-run 只记录）。key 在同一来源内唯一标识该作者。
+func (s *ImportService) resolveUser(ctx context.Context, source, key, name, email string, dryRun bool, st *importState) (uint, error) {
+	cacheKey := source + ":" + key
+	if id, ok := st.users[cacheKey]; ok {
+		return id, nil
+	}
+	remember := func(id uint) (uint, error) {
+		st.users[cacheKey] = id
+		if dryRun || id == 0 {
+			return id, nil
+		}
+		return id, s.importRepo.Save(ctx, source, model.ImportKindUser, key, id)
+	}
+
+	id, err := s.importRepo.LocalID(ctx, source, model.ImportKindUser, key)
+	if err != nil {
+		return 0, err
+	}
+	if id != 0 {
+		if _, err := s.userRepo.FindByID(ctx, id); err == nil {
+			st.users[cacheKey] = id
+			return id, nil
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, err
+		}
+	}
+
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email != "" {
+		u, err := s.userRepo.FindByEmail(ctx, email)
+		if err == nil {
+			return remember(u.ID)
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, err
+		}
+	}
+
+	if name == "" {
+		name = "guest"
+	}
+	st.report.NewUsers = append(st.report.NewUsers, name)
+	if dryRun {
+		return remember(0)
+	}
+	username, err := s.uniqueUsername(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+	if email == "" {
+		sum := sha1.Sum([]byte(cacheKey))
+		email = "imported-" + hex.EncodeToString(sum[:8]) + "@users.invalid"
+	}
+	u := &model.User{Username: username, Email: email, Password: importedPassword, Role: "user"}
+	if err := s.userRepo.Create(ctx, u); err != nil {
+		return 0, fmt.Errorf("create user %q: %w", username, err)
+	}
+	return remember(u.ID)
+}
+
+// uniqueUsername 由显示名生成未被占用的用户名。
+func (s *ImportService) uniqueUsername(ctx context.Context, name string) (string, error) {
+	base := util.Slugify(name)
+	if base == "" {
+		base = "guest"
+	}
+	if r := []rune(base); len(r) > 48 {
+		base = string(r[:48])
+	}
+	for i := 1; ; i++ {
+		candidate := base
+		if i > 1 {
+			candidate = fmt.Sprintf("%s-%d", base, i)
+		}
+		_, err := s.userRepo.FindByUsername(ctx, candidate)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+// extComment 待导入的外部评论（WordPress 与 Disqus 通用）。
+type extComment struct {
+	ID        string
+	Parent    string // 父评论的外部 ID，空表示顶层
+	PostID    uint   // 本地文章 ID（dry-run 中新文章为 0）
+	UserKey   string // 作者在来源内的唯一键
+	UserID    uint   // 由 UserKey 解析出的本地用户
+	Author    string
+	Email     string
+	Content   string
+	CreatedAt time.Time
+	Skip      bool // 未通过审核、垃圾、已删除等，不导入
+}
+
+// importComments 按"父评论先于子评论"的顺序导入评论并保留层级；父评论未导入时挂为顶层评论。
+func (s *ImportService) importComments(ctx context.Context, source string, comments []extComment, dryRun bool, st *importState) {
+	stats := st.report.Comments
+	byID := make(map[string]*extComment, len(comments))
+	for i := range comments {
+		byID[comments[i].ID] = &comments[i]
+	}
+	local := make(map[string]uint, len(comments))
+	done := make(map[string]bool, len(comments))
+
+	var visit func(c *extComment)
+	visit = func(c *extComment) {
+		if done[c.ID] {
+			return
+		}
+		done[c.ID] = true
+		if p, ok := byID[c.Parent]; ok {
+			visit(p)
+		}
+		if c.Skip {
+			stats.Skipped++
+			return
+		}
+		id, updated, err := s.importComment(ctx, source, c, local, dryRun)
+		if err != nil {
+			stats.Failed++
+			st.report.Warnings = append(st.report.Warnings, fmt.Sprintf("comment %s: %v", c.ID, err))
+			return
+		}
+		local[c.ID] = id
+		if updated {
+			stats.Updated++
+		} else {
+			stats.Created++
+		}
+	}
+
+	for i := range comments {
+		c := &comments[i]
+		if c.Skip {
+			continue
+		}
+		uid, err := s.resolveUser(ctx, source, c.UserKey, c.Author, c.Email, dryRun, st)
+		if err != nil {
+			done[c.ID] = true
+			stats.Failed++
+			st.report.Warnings = append(st.report.Warnings, fmt.Sprintf("comment %s: %v", c.ID, err))
+			continue
+		}
+		c.UserID = uid
+	}
+	for i := range comments {
+		visit(&comments[i])
+	}
+}
+
+// importComment 新建或更新单条评论，返回本地 ID 以及是否为更新。
+func (s *ImportService) importComment(ctx context.Context, source string, c *extComment, local map[string]uint, dryRun bool) (uint, bool, error) {
+	existingID, err := s.importRepo.LocalID(ctx, source, model.ImportKindComment, c.ID)
+	if err != nil {
+		return 0, false, err
+	}
+	var existing *model.Comment
+	if existingID != 0 {
+		existing, err = s.commentRepo.FindByID(ctx, existingID)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if _, terr := s.commentRepo.FindTrashedByID(ctx, existingID); terr == nil {
+				return 0, false, errors.New("comment is in trash")
+			}
+			existing = nil
+		case err != nil:
+			return 0, false, err
+		}
+	}
+
+	var parentID *uint
+	if c.Parent != "" {
+		pid, ok := local[c.Parent]
+		if !ok {
+			if pid, err = s.importRepo.LocalID(ctx, source, model.ImportKindComment, c.Parent); err != nil {
+				return 0, false, err
+			}
+		}
+		if pid != 0 && !dryRun {
+			parent, err := s.commentRepo.FindByID(ctx, pid)
+			if err != nil {
+				return 0, false, err
+			}
+			pid = flattenParent(parent, s.maxDepth)
+		}
+		if pid != 0 {
+			parentID = &pid
+		}
+	}
+	if dryRun {
+		if existing != nil {
+			return existing.Id, true, nil
+		}
+		return 0, false, nil
+	}
+
+	if existing != nil {
+		if !sameParent(existing.ParentId, parentID) {
+			if err := s.commentRepo.Move(ctx, existing, parentID); err != nil {
+				return 0, false, err
+			}
+		}
+		existing.Content = c.Content
+		existing.UserId = c.UserID
+		return existing.Id, true, s.commentRepo.Save(ctx, existing)
+	}
+
+	comment := &model.Comment{
+		Content:   c.Content,
+		UserId:    c.UserID,
+		PostId:    c.PostID,
+		ParentId:  parentID,
+		Status:    model.CommentStatusApproved,
+		CreatedAt: c.CreatedAt,
+	}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.commentRepo.WithDB(tx).Create(ctx, comment); err != nil {
+			return err
+		}
+		return s.importRepo.WithDB(tx).Save(ctx, source, model.ImportKindComment, c.ID, comment.Id)
+	})
+	return comment.Id, false, err
+}
+
+// sameParent 判断两个可空父评论ID是否相同。
+func sameParent(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
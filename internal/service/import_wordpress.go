@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"go-blog/internal/dto"
+	"go-blog/internal/importer"
+	"go-blog/internal/model"
+	"gorm.io/gorm"
+)
+
+// ImportWordPress 导入 WordPress 导出文件（WXR）：分类、标签、作者、文章与页面以及层级评论。
+// 原始 ID 记录在 import_records 中，重复导入会更新已导入的记录而不是重复创建。
+func (s *ImportService) ImportWordPress(ctx context.Context, r io.Reader, opts ImportOptions) (*dto.ImportReport, error) {
+	if err := s.checkOwner(ctx, opts.UserID); err != nil {
+		return nil, err
+	}
+	opts.applyDefaults()
+
+	wxr, err := importer.ParseWXR(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+	st := newImportState(opts.DryRun)
+	st.report.Comments = &dto.ImportCommentStats{}
+
+	// 1. 分类（父分类先于子分类）与标签
+	if err := s.importWPCategories(ctx, wxr.Categories, opts.DryRun, st); err != nil {
+		return nil, err
+	}
+	for _, t := range wxr.Tags {
+		if _, err := s.resolveTag(ctx, t.Name, t.Slug, opts.DryRun, st); err != nil {
+			return nil, err
+		}
+	}
+
+	// 2. 作者：按 login 映射为本地用户
+	authors := make(map[string]uint, len(wxr.Authors))
+	authorLogins := make(map[string]string, len(wxr.Authors))
+	for _, a := range wxr.Authors {
+		uid, err := s.resolveUser(ctx, model.ImportSourceWordPress, "login:"+a.Login, a.Login, a.Email, opts.DryRun, st)
+		if err != nil {
+			return nil, err
+		}
+		authors[a.Login] = uid
+		authorLogins[a.ID] = a.Login
+	}
+
+	// 3. 文章与页面，随后导入其评论
+	var comments []extComment
+	skipped := 0
+	for i := range wxr.Items {
+		item := &wxr.Items[i]
+		if item.Type != importer.WPTypePost && item.Type != importer.WPTypePage {
+			continue // 附件、菜单等
+		}
+		status, visibility, ok := wpStatus(item.Status, item.Password)
+		if !ok {
+			skipped++
+			continue
+		}
+		res := s.importWPItem(ctx, item, status, visibility, authors, opts, st)
+		st.report.Posts = append(st.report.Posts, res)
+		if res.Action == dto.ImportActionError {
+			continue
+		}
+		for _, c := range item.Comments {
+			ext := extComment{
+				ID:        c.ID,
+				PostID:    res.PostId,
+				Author:    c.Author,
+				Email:     c.AuthorEmail,
+				UserKey:   commenterKey(c.AuthorEmail, "", c.Author),
+				Content:   c.Content,
+				CreatedAt: c.Date,
+				Skip:      !c.IsApproved() || c.IsPing() || strings.TrimSpace(c.Content) == "",
+			}
+			if c.Parent != "" && c.Parent != "0" {
+				ext.Parent = c.Parent
+			}
+			// 站内注册用户的评论归属到对应作者
+			if login, ok := authorLogins[c.UserID]; ok && c.UserID != "0" {
+				ext.UserKey = "login:" + login
+				ext.Author = login
+			}
+			comments = append(comments, ext)
+		}
+	}
+	if skipped > 0 {
+		st.report.Warnings = append(st.report.Warnings, fmt.Sprintf("%d trashed or auto-draft items skipped", skipped))
+	}
+
+	s.importComments(ctx, model.ImportSourceWordPress, comments, opts.DryRun, st)
+	return s.finish(st), nil
+}
+
+// importWPCategories 导入分类并保留父子关系；父分类不在导出中时作为顶层分类。
+func (s *ImportService) importWPCategories(ctx context.Context, terms []importer.WPTerm, dryRun bool, st *importState) error {
+	bySlug := make(map[string]uint, len(terms))
+	pending := terms
+	for len(pending) > 0 {
+		var next []importer.WPTerm
+		for _, t := range pending {
+			var parentID *uint
+			if t.Parent != "" {
+				pid, ok := bySlug[t.Parent]
+				if !ok {
+					next = append(next, t)
+					continue
+				}
+				if pid != 0 {
+					parentID = &pid
+				}
+			}
+			id, err := s.resolveCategory(ctx, t.Name, t.Slug, parentID, dryRun, st)
+			if err != nil {
+				return err
+			}
+			bySlug[t.Slug] = id
+		}
+		if len(next) == len(pending) {
+			// 剩余分类的父分类缺失（或成环），去掉父分类后再导入
+			for i := range next {
+				next[i].Parent = ""
+			}
+		}
+		pending = next
+	}
+	return nil
+}
+
+// importWPItem 新建或更新一篇 WordPress 文章/页面。
+func (s *ImportService) importWPItem(ctx context.Context, item *importer.WPItem, status, visibility string, authors map[string]uint, opts ImportOptions, st *importState) dto.ImportPostResult {
+	res := dto.ImportPostResult{
+		Path:   "wordpress:" + item.Type + "/" + item.ID,
+		Slug:   item.Slug,
+		Title:  item.Title,
+		Status: status,
+	}
+	fail := func(err error) dto.ImportPostResult {
+		res.Action = dto.ImportActionError
+		res.Error = err.Error()
+		return res
+	}
+	if item.ID == "" {
+		return fail(errors.New("missing wp:post_id"))
+	}
+	title := item.Title
+	if title == "" {
+		title = item.Slug
+	}
+	if item.Type == importer.WPTypePage {
+		res.Warnings = append(res.Warnings, "page imported as a regular post")
+	}
+
+	// 1. 分类与标签
+	categoryName, categorySlug := opts.DefaultCategory, ""
+	if len(item.Categories) > 0 {
+		categoryName, categorySlug = item.Categories[0].Name, item.Categories[0].Slug
+		if len(item.Categories) > 1 {
+			names := make([]string, 0, len(item.Categories)-1)
+			for _, c := range item.Categories[1:] {
+				names = append(names, c.Name)
+			}
+			res.Warnings = append(res.Warnings, fmt.Sprintf("only the first category is kept, ignored: %s", strings.Join(names, ", ")))
+		}
+	}
+	categoryID, err := s.resolveCategory(ctx, categoryName, categorySlug, nil, opts.DryRun, st)
+	if err != nil {
+		return fail(err)
+	}
+	tagIDs := make([]uint, 0, len(item.Tags))
+	for _, t := range item.Tags {
+		id, err := s.resolveTag(ctx, t.Name, t.Slug, opts.DryRun, st)
+		if err != nil {
+			return fail(err)
+		}
+		if id != 0 {
+			tagIDs = append(tagIDs, id)
+		}
+	}
+
+	owner := authors[item.Creator]
+	if owner == 0 {
+		owner = opts.UserID
+	}
+
+	// 2. 通过导入记录定位已导入的文章
+	localID, err := s.importRepo.LocalID(ctx, model.ImportSourceWordPress, model.ImportKindPost, item.ID)
+	if err != nil {
+		return fail(err)
+	}
+	var existing *model.Post
+	if localID != 0 {
+		existing, err = s.postRepo.FindByID(ctx, localID)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if _, terr := s.postRepo.FindTrashedByID(ctx, localID); terr == nil {
+				return fail(fmt.Errorf("post %d is in trash", localID))
+			}
+			existing = nil
+		case err != nil:
+			return fail(err)
+		}
+	}
+
+	if existing == nil {
+		res.Action = dto.ImportActionCreate
+		if opts.DryRun {
+			return res
+		}
+		post := &model.Post{
+			Title:      title,
+			Content:    item.Content,
+			Slug:       item.Slug,
+			CategoryId: categoryID,
+			Status:     status,
+			UserID:     owner,
+			Version:    1,
+		}
+		if err := applyVisibility(post, &visibility, &item.Password); err != nil {
+			return fail(err)
+		}
+		if !item.Date.IsZero() {
+			post.CreatedAt = item.Date
+		}
+		ref := &importRef{source: model.ImportSourceWordPress, kind: model.ImportKindPost, id: item.ID}
+		if err := s.createPost(ctx, post, tagIDs, ref); err != nil {
+			return fail(err)
+		}
+		res.PostId = post.ID
+		return res
+	}
+
+	res.Action = dto.ImportActionUpdate
+	res.PostId = existing.ID
+	if opts.DryRun {
+		return res
+	}
+	if err := applyVisibility(existing, &visibility, &item.Password); err != nil {
+		return fail(err)
+	}
+	if err := s.updatePost(ctx, existing, title, item.Content, categoryID, status, tagIDs, opts.UserID); err != nil {
+		return fail(err)
+	}
+	return res
+}
+
+// wpStatus 将 WordPress 文章状态与访问密码映射为本地状态与可见性：设置了密码的非私密文章导入为密码保护；
+// 回收站与自动草稿不导入。
+func wpStatus(status, password string) (string, string, bool) {
+	visibility := model.PostVisibilityPublic
+	if password != "" {
+		visibility = model.PostVisibilityPassword
+	}
+	switch status {
+	case "publish":
+		return model.PostStatusPublished, visibility, true
+	case "private":
+		return model.PostStatusPublished, model.PostVisibilityPrivate, true
+	case "pending":
+		return model.PostStatusInReview, visibility, true
+	case "draft", "future":
+		return model.PostStatusDraft, visibility, true
+	}
+	return "", "", false
+}
+
+// commenterKey 评论者在来源内的唯一键：优先邮箱，其次用户名，最后显示名。
+func commenterKey(email, username, name string) string {
+	switch {
+	case email != "":
+		return "email:" + strings.ToLower(email)
+	case username != "":
+		return "user:" + username
+	case name != "":
+		return "name:" + name
+	}
+	return "anonymous"
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"go-blog/internal/model"
+	"go-blog/internal/repository"
+	"go-blog/internal/util"
+)
+
+// MentionNotifier 接收刚公开的提及，用于通知被提及的用户；实现应尽快返回，耗时操作自行异步处理。
+type MentionNotifier interface {
+	NotifyMentions(ctx context.Context, mentions []model.Mention)
+}
+
+// MentionService 解析并维护文章与评论中的 @提及。
+type MentionService struct {
+	Users    *repository.UserRepository
+	Mentions *repository.MentionRepository
+	Blocks   *repository.BlockRepository
+	Notifier MentionNotifier // 可为 nil，表示只记录不通知
+}
+
+// NewMentionService 构造提及服务。
+func NewMentionService(users *repository.UserRepository, mentions *repository.MentionRepository, blocks *repository.BlockRepository, notifier MentionNotifier) *MentionService {
+	return &MentionService{Users: users, Mentions: mentions, Blocks: blocks, Notifier: notifier}
+}
+
+// Sync 解析内容中的 @username 并整体替换来源的提及记录，忽略不存在的用户、作者本人以及屏蔽了作者的用户；
+// public 为 true 时通知尚未通知过的被提及者。返回当前的提及实体（按正文中出现的顺序）。
+func (s *MentionService) Sync(ctx context.Context, targetType string, targetID, authorID uint, content string, public bool) ([]model.MentionRef, error) {
+	names := util.ParseMentions(content)
+	users, err := s.Users.FindByUsernames(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]model.User, len(users))
+	userIDs := make([]uint, 0, len(users))
+	for _, u := range users {
+		byName[strings.ToLower(u.Username)] = u
+		userIDs = append(userIDs, u.ID)
+	}
+	blockers, err := s.Blocks.BlockersOf(ctx, userIDs, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	mentions := make([]model.Mention, 0, len(names))
+	refs := make([]model.MentionRef, 0, len(names))
+	for _, name := range names {
+		u, ok := byName[strings.ToLower(name)]
+		if !ok || u.ID == authorID || blockers[u.ID] {
+			continue
+		}
+		m := model.Mention{
+			TargetType: targetType,
+			TargetId:   targetID,
+			UserId:     u.ID,
+			Username:   u.Username,
+			AuthorId:   authorID,
+		}
+		mentions = append(mentions, m)
+		refs = append(refs, m.Ref())
+	}
+	if err := s.Mentions.Replace(ctx, targetType, targetID, mentions); err != nil {
+		return nil, err
+	}
+	if public {
+		if err := s.Publish(ctx, targetType, targetID); err != nil {
+			return nil, err
+		}
+	}
+	return refs, nil
+}
+
+// Publish 来源公开后（评论通过审核、文章发布）通知其中尚未通知过的被提及者，每条提及只通知一次。
+func (s *MentionService) Publish(ctx context.Context, targetType string, ids ...uint) error {
+	pending, err := s.Mentions.ClaimPending(ctx, targetType, ids)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 && s.Notifier != nil {
+		s.Notifier.NotifyMentions(ctx, pending)
+	}
+	return nil
+}
+
+// Refs 批量查询来源的提及实体：来源ID -> 提及列表。
+func (s *MentionService) Refs(ctx context.Context, targetType string, ids []uint) (map[uint][]model.MentionRef, error) {
+	rows, err := s.Mentions.ListByTargets(ctx, targetType, ids)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[uint][]model.MentionRef, len(rows))
+	for id, list := range rows {
+		for _, m := range list {
+			out[id] = append(out[id], m.Ref())
+		}
+	}
+	return out, nil
+}
+
+// mentionsPublic 判断文章内容（及其下评论）中的提及是否可以通知：已发布且非私密。
+// 私密文章的读者范围有限，被提及者未必能阅读，不发送通知。
+func mentionsPublic(post *model.Post) bool {
+	return post.Status == model.PostStatusPublished && post.Visibility != model.PostVisibilityPrivate
+}
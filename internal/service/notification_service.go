@@ -0,0 +1,359 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"go-blog/internal/dto"
+	"go-blog/internal/model"
+	"go-blog/internal/repository"
+	"gorm.io/gorm"
+)
+
+// 通知相关错误定义。
+var (
+	ErrNotificationNotFound    = errors.New("notification not found")
+	ErrInvalidNotificationType = errors.New("invalid notification type")
+)
+
+// notificationDetailLen 通知中评论摘要的最大字符数。
+const notificationDetailLen = 100
+
+// NotificationEvent 一次需要通知的事件。
+type NotificationEvent struct {
+	Type       string
+	UserId     uint // 接收人
+	ActorId    uint // 触发人，0 表示系统
+	TargetType string
+	TargetId   uint
+	PostId     uint
+	Detail     string
+	GroupKey   string // 合并键，相同键的未读通知合并为一条
+}
+
+// NotificationService 记录站内通知并提供查询、已读与偏好设置。
+type NotificationService struct {
+	repo        *repository.NotificationRepository
+	postRepo    *repository.PostRepository
+	commentRepo *repository.CommentRepository
+	blocks      *repository.BlockRepository
+	streams     *StreamService
+}
+
+// NewNotificationService 构造通知服务，streams 用于向在线用户实时推送（可为 nil）。
+func NewNotificationService(repo *repository.NotificationRepository, postRepo *repository.PostRepository, commentRepo *repository.CommentRepository, blocks *repository.BlockRepository, streams *StreamService) *NotificationService {
+	return &NotificationService{repo: repo, postRepo: postRepo, commentRepo: commentRepo, blocks: blocks, streams: streams}
+}
+
+// Notify 记录事件：跳过触发人本人、接收人关闭的类型以及接收人屏蔽的触发人。
+// 通知是业务操作的附带效果，失败只记录日志；s 为 nil 时不做任何事。
+func (s *NotificationService) Notify(ctx context.Context, events ...NotificationEvent) {
+	if s == nil {
+		return
+	}
+	for _, e := range events {
+		if err := s.record(ctx, e); err != nil {
+			log.Printf("record %s notification for user %d error: %v", e.Type, e.UserId, err)
+		}
+	}
+}
+
+func (s *NotificationService) record(ctx context.Context, e NotificationEvent) error {
+	if e.UserId == 0 || e.UserId == e.ActorId {
+		return nil
+	}
+	enabled, err := s.repo.Enabled(ctx, e.UserId, e.Type)
+	if err != nil || !enabled {
+		return err
+	}
+	if e.ActorId > 0 {
+		blockers, err := s.blocks.BlockersOf(ctx, []uint{e.UserId}, e.ActorId)
+		if err != nil || blockers[e.UserId] {
+			return err
+		}
+	}
+	if e.GroupKey == "" {
+		e.GroupKey = notificationGroupKey(e.Type, e.TargetType, e.TargetId)
+	}
+	n := &model.Notification{
+		UserId:     e.UserId,
+		Type:       e.Type,
+		GroupKey:   e.GroupKey,
+		TargetType: e.TargetType,
+		TargetId:   e.TargetId,
+		PostId:     e.PostId,
+		ActorId:    e.ActorId,
+		Detail:     truncate(e.Detail, 255),
+	}
+	if err := s.repo.Record(ctx, n); err != nil {
+		return err
+	}
+	s.push(ctx, e.UserId, n.Id)
+	return nil
+}
+
+// push 向用户的通知流推送写入（或合并）后的通知及最新未读数。
+func (s *NotificationService) push(ctx context.Context, uid, id uint) {
+	if s.streams == nil {
+		return
+	}
+	n, err := s.repo.FindByID(ctx, uid, id)
+	if err != nil {
+		log.Printf("push notification %d error: %v", id, err)
+		return
+	}
+	s.streams.Publish(ctx, UserTopic(uid), StreamNotification, ToNotificationResp(n))
+	s.pushUnread(ctx, uid)
+}
+
+// pushUnread 推送用户的未读数，使多个在线客户端的角标保持一致。
+func (s *NotificationService) pushUnread(ctx context.Context, uid uint) {
+	if s.streams == nil {
+		return
+	}
+	unread, err := s.UnreadCount(ctx, uid)
+	if err != nil {
+		log.Printf("push unread count for user %d error: %v", uid, err)
+		return
+	}
+	s.streams.Publish(ctx, UserTopic(uid), StreamUnread, unread)
+}
+
+func notificationGroupKey(typ, targetType string, targetID uint) string {
+	return typ + ":" + targetType + ":" + strconv.FormatUint(uint64(targetID), 10)
+}
+
+// CommentPublished 评论公开（创建即通过或审核通过）后通知：被回复评论的作者（同一评论下的回复合并），
+// 以及文章所有者（同一文章下的评论合并；所有者已作为被回复者通知时不重复）。
+func (s *NotificationService) CommentPublished(ctx context.Context, comment *model.Comment, post *model.Post) {
+	if s == nil {
+		return
+	}
+	excerpt := truncate(comment.Content, notificationDetailLen)
+	var events []NotificationEvent
+	var replied uint
+	if comment.ParentId != nil {
+		parent, err := s.commentRepo.FindByID(ctx, *comment.ParentId)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("notify reply to comment %d error: %v", *comment.ParentId, err)
+		}
+		if err == nil && !parent.Tombstone {
+			replied = parent.UserId
+			events = append(events, NotificationEvent{
+				Type:       model.NotificationReply,
+				UserId:     parent.UserId,
+				ActorId:    comment.UserId,
+				TargetType: model.MentionTargetComment,
+				TargetId:   comment.Id,
+				PostId:     comment.PostId,
+				Detail:     excerpt,
+				GroupKey:   notificationGroupKey(model.NotificationReply, model.MentionTargetComment, parent.Id),
+			})
+		}
+	}
+	if post.UserID != replied {
+		events = append(events, NotificationEvent{
+			Type:       model.NotificationComment,
+			UserId:     post.UserID,
+			ActorId:    comment.UserId,
+			TargetType: model.MentionTargetComment,
+			TargetId:   comment.Id,
+			PostId:     post.ID,
+			Detail:     excerpt,
+			GroupKey:   notificationGroupKey(model.NotificationComment, model.MentionTargetPost, post.ID),
+		})
+	}
+	s.Notify(ctx, events...)
+}
+
+// NotifyMentions 实现 MentionNotifier：每条提及通知一次，不合并。
+func (s *NotificationService) NotifyMentions(ctx context.Context, mentions []model.Mention) {
+	for _, m := range mentions {
+		e := NotificationEvent{
+			Type:       model.NotificationMention,
+			UserId:     m.UserId,
+			ActorId:    m.AuthorId,
+			TargetType: m.TargetType,
+			TargetId:   m.TargetId,
+			PostId:     m.TargetId,
+		}
+		if m.TargetType == model.MentionTargetComment {
+			c, err := s.commentRepo.FindByID(ctx, m.TargetId)
+			if err != nil {
+				log.Printf("notify mention in comment %d error: %v", m.TargetId, err)
+				continue
+			}
+			e.PostId, e.Detail = c.PostId, truncate(c.Content, notificationDetailLen)
+		} else if p, err := s.postRepo.FindByID(ctx, m.TargetId); err == nil {
+			e.Detail = p.Title
+		}
+		s.Notify(ctx, e)
+	}
+}
+
+// ListNotifications 分页返回用户的通知及未读数。
+func (s *NotificationService) ListNotifications(ctx context.Context, uid uint, unreadOnly bool, page, pageSize int) (*dto.NotificationPageResp, error) {
+	list, total, err := s.repo.List(ctx, uid, unreadOnly, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	unread, err := s.UnreadCount(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	resp := &dto.NotificationPageResp{
+		Page:         page,
+		PageSize:     pageSize,
+		Total:        total,
+		Unread:       unread.Unread,
+		UnreadByType: unread.UnreadByType,
+		List:         make([]dto.NotificationResp, 0, len(list)),
+	}
+	for i := range list {
+		resp.List = append(resp.List, ToNotificationResp(&list[i]))
+	}
+	return resp, nil
+}
+
+// UnreadCount 返回用户的未读通知总数与各类型未读数。
+func (s *NotificationService) UnreadCount(ctx context.Context, uid uint) (*dto.UnreadCountResp, error) {
+	counts, err := s.repo.UnreadCounts(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	resp := &dto.UnreadCountResp{UnreadByType: counts}
+	for _, n := range counts {
+		resp.Unread += n
+	}
+	return resp, nil
+}
+
+// MarkRead 将一条通知标记为已读，只能操作自己的通知。
+func (s *NotificationService) MarkRead(ctx context.Context, uid, id uint) error {
+	if err := s.repo.MarkRead(ctx, uid, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotificationNotFound
+		}
+		return err
+	}
+	s.pushUnread(ctx, uid)
+	return nil
+}
+
+// MarkAllRead 将全部未读通知标记为已读，返回标记的条数。
+func (s *NotificationService) MarkAllRead(ctx context.Context, uid uint) (int64, error) {
+	n, err := s.repo.MarkAllRead(ctx, uid)
+	if err != nil {
+		return 0, err
+	}
+	if n > 0 {
+		s.pushUnread(ctx, uid)
+	}
+	return n, nil
+}
+
+// Preferences 返回全部通知类型的开关（未设置的为开启）。
+func (s *NotificationService) Preferences(ctx context.Context, uid uint) (map[string]bool, error) {
+	set, err := s.repo.Preferences(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]bool, len(model.NotificationTypes))
+	for _, typ := range model.NotificationTypes {
+		enabled, ok := set[typ]
+		out[typ] = !ok || enabled
+	}
+	return out, nil
+}
+
+// UpdatePreferences 更新通知开关，返回更新后的全部开关；包含未知类型时返回 ErrInvalidNotificationType。
+func (s *NotificationService) UpdatePreferences(ctx context.Context, uid uint, prefs map[string]bool) (map[string]bool, error) {
+	for typ := range prefs {
+		if !validNotificationType(typ) {
+			return nil, ErrInvalidNotificationType
+		}
+	}
+	if err := s.repo.SetPreferences(ctx, uid, prefs); err != nil {
+		return nil, err
+	}
+	return s.Preferences(ctx, uid)
+}
+
+func validNotificationType(typ string) bool {
+	for _, t := range model.NotificationTypes {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// ToNotificationResp 转换通知并生成描述文字。
+func ToNotificationResp(n *model.Notification) dto.NotificationResp {
+	resp := dto.NotificationResp{
+		Id:         n.Id,
+		Type:       n.Type,
+		Message:    notificationMessage(n),
+		TargetType: n.TargetType,
+		TargetId:   n.TargetId,
+		PostId:     n.PostId,
+		ActorCount: n.ActorCount,
+		Count:      n.Count,
+		Detail:     n.Detail,
+		Read:       n.ReadAt != nil,
+		ReadAt:     n.ReadAt,
+		CreatedAt:  n.CreatedAt,
+		UpdatedAt:  n.UpdatedAt,
+	}
+	if n.Actor != nil {
+		resp.Actor = &dto.UserBrief{Id: n.Actor.ID, Username: n.Actor.Username}
+	}
+	return resp
+}
+
+// notificationMessage 生成通知描述，合并了多人的事件显示为 "bob 等 5 人……"。
+func notificationMessage(n *model.Notification) string {
+	who := "有人"
+	if n.Actor != nil {
+		who = n.Actor.Username
+	}
+	if n.ActorCount > 1 {
+		who = fmt.Sprintf("%s 等 %d 人", who, n.ActorCount)
+	}
+	noun := "文章"
+	if n.TargetType == model.MentionTargetComment {
+		noun = "评论"
+	}
+	switch n.Type {
+	case model.NotificationReply:
+		return who + " 回复了你的评论"
+	case model.NotificationComment:
+		return who + " 评论了你的文章"
+	case model.NotificationMention:
+		return who + " 在" + noun + "中提到了你"
+	case model.NotificationReaction:
+		return who + " 对你的" + noun + "表态"
+	case model.NotificationModeration:
+		return moderationMessage(noun, n.Detail)
+	}
+	return who + " 有新动态"
+}
+
+func moderationMessage(noun, status string) string {
+	switch status {
+	case model.CommentStatusApproved:
+		return "你的" + noun + "已通过审核"
+	case model.CommentStatusRejected:
+		return "你的" + noun + "未通过审核"
+	case model.CommentStatusSpam:
+		return "你的" + noun + "被标记为垃圾信息"
+	case model.PostStatusDraft:
+		return "你的" + noun + "被退回修改"
+	case model.PostStatusPublished:
+		return "你的" + noun + "已发布"
+	}
+	return "你的" + noun + "状态变为 " + status
+}
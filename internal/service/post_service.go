@@ -3,49 +3,106 @@ package service
 import (
 	"context"
 	"errors"
+	"log"
+
 	"go-blog/internal/dto"
 	"go-blog/internal/model"
 	"go-blog/internal/repository"
+	"go-blog/internal/spam"
 	"gorm.io/gorm"
 )
 
 // 文章业务相关错误定义。
 var (
-	ErrPostNotFound = errors.New("post not found")
-	ErrForbidden    = errors.New("forbidden")
+	ErrPostNotFound   = errors.New("post not found")
+	ErrForbidden      = errors.New("forbidden")
+	ErrUserNotFound   = errors.New("user not found")
+	ErrAuthorExists   = errors.New("author already exists")
+	ErrAuthorNotFound = errors.New("author not found")
+	ErrPostConflict   = errors.New("post version conflict")
 )
 
+// PostConflictError 文章版本冲突，携带服务端当前版本号。
+type PostConflictError struct {
+	Current uint64
+}
+
+func (e *PostConflictError) Error() string { return ErrPostConflict.Error() }
+
+// Is 使 errors.Is(err, ErrPostConflict) 成立。
+func (e *PostConflictError) Is(target error) bool { return target == ErrPostConflict }
+
 // PostService 负责文章相关的业务逻辑
 type PostService struct {
-	DB   *gorm.DB
-	Repo *repository.PostRepository
+	DB        *gorm.DB
+	Repo      *repository.PostRepository
+	Views     *ViewCounter
+	Reactions *ReactionService
+	Series    *SeriesService
+	UserRepo  *repository.UserRepository
+	Related   *RelatedService
+	Spam      spam.SpamChecker // 可为 nil，表示不检测文章
+	Mentions  *MentionService
+	Notifier  *NotificationService
+	Workflow  Workflow
 }
 
-// NewPostService 构造文章服务，注入数据库和仓库。
-func NewPostService(db *gorm.DB, repo *repository.PostRepository) *PostService {
+// NewPostService 构造文章服务，注入数据库、仓库、浏览计数器、表态、系列、相关推荐、垃圾检测、提及与通知服务，并读取审核流程配置。
+func NewPostService(db *gorm.DB, repo *repository.PostRepository, userRepo *repository.UserRepository, views *ViewCounter, reactions *ReactionService, series *SeriesService, related *RelatedService, spamChecker spam.SpamChecker, mentions *MentionService, notifier *NotificationService) *PostService {
 	return &PostService{
-		DB:   db,
-		Repo: repo,
+		DB:        db,
+		Repo:      repo,
+		Views:     views,
+		Reactions: reactions,
+		Series:    series,
+		UserRepo:  userRepo,
+		Related:   related,
+		Spam:      spamChecker,
+		Mentions:  mentions,
+		Notifier:  notifier,
+		Workflow:  LoadWorkflow(),
 	}
 }
 
-// CreatePost 创建文章（带标签，使用事务保证文章和标签绑定一致）
-func (s *PostService) CreatePost(ctx context.Context, uid uint, req dto.CreatePostReq) (*model.Post, error) {
+// CreatePost 创建文章（带标签，使用事务保证文章和标签绑定一致），初始状态受审核流程约束
+func (s *PostService) CreatePost(ctx context.Context, uid uint, role string, req dto.CreatePostReq) (*model.Post, error) {
+	if req.Status == "" {
+		req.Status = model.PostStatusDraft
+	}
+	if err := s.Workflow.CheckInitial(req.Status, role); err != nil {
+		return nil, err
+	}
+	if err := s.checkSpam(ctx, uid, role, req.Title, req.Content); err != nil {
+		return nil, err
+	}
 	post := &model.Post{
 		Title:      req.Title,
 		Content:    req.Content,
 		CategoryId: req.CategoryId,
 		Status:     req.Status,
 		UserID:     uid,
+		Version:    1,
+
+		CommentModeration: req.CommentModeration,
+	}
+	if err := applyVisibility(post, &req.Visibility, &req.Password); err != nil {
+		return nil, err
 	}
 
 	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		repoTx := s.Repo.WithDB(tx)
 
-		// 1. 写文章
+		// 1. 写文章，并登记所有者
 		if err := repoTx.Create(ctx, post); err != nil {
 			return err
 		}
+		if err := repoTx.AddAuthor(ctx, &model.PostAuthor{
+			PostId: post.ID,
+			UserId: uid,
+			Role:   model.PostAuthorOwner,
+		}); err != nil {
+			return err
+		}
 
 		// 2. 处理标签
 		if len(req.TagIds) > 0 {
@@ -54,39 +111,106 @@ func (s *PostService) CreatePost(ctx context.Context, uid uint, req dto.CreatePo
 			}
 		}
 
-		return nil
+		// 3. 私密文章的指定读者
+		if len(req.ViewerIds) > 0 {
+			if err := repoTx.ReplaceViewers(ctx, post.ID, req.ViewerIds); err != nil {
+				return err
+			}
+		}
+
+		// 4. 记录初始状态
+		return repoTx.AddTransition(ctx, &model.PostTransition{
+			PostId:   post.ID,
+			UserId:   uid,
+			ToStatus: post.Status,
+		})
 	})
 	if err != nil {
 		return nil, err
 	}
+	if len(req.TagIds) > 0 {
+		s.invalidateRelated()
+	}
+	s.syncMentions(ctx, post)
 
 	return post, nil
 }
 
-// GetAllPosts 获取所有文章（预加载作者）
-func (s *PostService) GetAllPosts(ctx context.Context) ([]model.Post, error) {
-	return s.Repo.FindAllWithUser(ctx)
+// GetAllPosts 获取当前用户可见的所有文章（预加载作者），并填充当前用户视角的表态信息
+func (s *PostService) GetAllPosts(ctx context.Context, uid uint) ([]model.Post, error) {
+	posts, err := s.Repo.FindAllWithUser(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	maskProtected(uid, postPtrs(posts)...)
+	if err := s.Reactions.AttachPostReactions(ctx, uid, postPtrs(posts)...); err != nil {
+		return nil, err
+	}
+	if err := s.attachMentions(ctx, postPtrs(posts)...); err != nil {
+		return nil, err
+	}
+	return posts, nil
 }
 
-// GetPostByID 根据 id 查询文章详情（预加载作者）
-func (s *PostService) GetPostByID(ctx context.Context, id uint) (*model.Post, error) {
-	// 这里直接用 repo 的基础查询
-	post, err := s.Repo.FindByID(ctx, id)
+// GetPostByID 根据 id 查询文章详情（预加载作者、分类、标签与合著者）；
+// 私密文章仅作者与指定读者可见，密码保护文章需提供 password
+func (s *PostService) GetPostByID(ctx context.Context, uid, id uint, password string) (*model.Post, error) {
+	post, err := s.Repo.FindDetailByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrPostNotFound
 		}
 		return nil, err
 	}
-	// 如果你希望这里也预加载 User，可以：
-	// 1) 把 FindByID 改成 Preload("User")
-	// 2) 或者另写一个 FindByIDWithUser
+	if err := authorizePostRead(ctx, s.Repo, post, uid, password); err != nil {
+		return nil, err
+	}
+	if s.Views != nil {
+		post.ViewCount += s.Views.Pending(post.ID)
+	}
+	if err := s.Reactions.AttachPostReactions(ctx, uid, post); err != nil {
+		return nil, err
+	}
+	if err := s.attachMentions(ctx, post); err != nil {
+		return nil, err
+	}
+	nav, err := s.Series.NavForPost(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	post.Series = nav
 	return post, nil
 }
 
-// UpdatePost 更新文章：仅作者本人可更新，空字段不覆盖，标签一起维护
-func (s *PostService) UpdatePost(ctx context.Context, uid, id uint, req dto.UpdatePostReq) (*model.Post, error) {
+// RecordView 记录一次文章浏览（仅统计已发布文章），visitor 用于去重。
+func (s *PostService) RecordView(post *model.Post, visitor string) {
+	if s.Views == nil || post.Status != "published" {
+		return
+	}
+	if s.Views.Record(post.ID, visitor) {
+		post.ViewCount++
+	}
+}
+
+// UpdatePost 更新文章：所有者与合著者可更新，空字段不覆盖，标签一起维护；
+// 携带 req.Version 时只有版本号一致才会更新，否则返回 *PostConflictError；状态变更按审核流程校验并记录，
+// 启用审核流程时作者修改已批准文章的标题或正文会退回 in_review
+func (s *PostService) UpdatePost(ctx context.Context, uid uint, role string, id uint, req dto.UpdatePostReq) (*model.Post, error) {
+	if req.Title != nil || req.Content != nil {
+		var title, content string
+		if req.Title != nil {
+			title = *req.Title
+		}
+		if req.Content != nil {
+			content = *req.Content
+		}
+		if err := s.checkSpam(ctx, uid, role, title, content); err != nil {
+			return nil, err
+		}
+	}
+
 	var post *model.Post
+	var fromStatus string
 
 	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		repoTx := s.Repo.WithDB(tx)
@@ -101,47 +225,186 @@ func (s *PostService) UpdatePost(ctx context.Context, uid, id uint, req dto.Upda
 		}
 		post = p
 
-		// 2. 鉴权：只能作者自己改
-		if post.UserID != uid {
+		// 2. 鉴权：所有者或合著者
+		ok, err := isPostAuthor(ctx, repoTx, post, uid)
+		if err != nil {
+			return err
+		}
+		if !ok {
 			return ErrForbidden
 		}
 
-		// 3. 按需更新字段
+		// 3. 版本校验
+		if req.Version != nil && *req.Version != post.Version {
+			return &PostConflictError{Current: post.Version}
+		}
+
+		// 4. 按需更新字段；已批准或已发布的文章内容被改动时先退回审核
+		edited := (req.Title != nil && *req.Title != post.Title) || (req.Content != nil && *req.Content != post.Content)
 		if req.Title != nil {
 			post.Title = *req.Title
 		}
 		if req.Content != nil {
 			post.Content = *req.Content
 		}
+		fromStatus = post.Status
+		var transitions []model.PostTransition
+		if edited && s.Workflow.RequiresReReview(post.Status, role) {
+			transitions = append(transitions, model.PostTransition{
+				FromStatus: post.Status,
+				ToStatus:   model.PostStatusInReview,
+				Comment:    "内容已修改，重新提交审核",
+			})
+			post.Status = model.PostStatusInReview
+		}
 		if req.Status != nil {
+			if err := s.Workflow.CheckTransition(post.Status, *req.Status, role, true, ""); err != nil {
+				return err
+			}
+			if *req.Status != post.Status {
+				transitions = append(transitions, model.PostTransition{FromStatus: post.Status, ToStatus: *req.Status})
+			}
 			post.Status = *req.Status
 		}
 		if req.CategoryID != nil {
 			post.CategoryId = *req.CategoryID
 		}
+		if err := applyVisibility(post, req.Visibility, req.Password); err != nil {
+			return err
+		}
+		if req.CommentModeration != nil {
+			post.CommentModeration = *req.CommentModeration
+			if post.CommentModeration == "inherit" {
+				post.CommentModeration = ""
+			}
+		}
 
-		// 4. 保存文章
-		if err := repoTx.Save(ctx, post); err != nil {
+		// 5. 条件更新：并发写入导致版本变化时视为冲突
+		updated, current, err := repoTx.UpdateIfVersion(ctx, post, post.Version)
+		if err != nil {
 			return err
 		}
+		if !updated {
+			return &PostConflictError{Current: current}
+		}
 
-		// 5. 标签
+		// 6. 标签
 		if err := repoTx.ReplaceTags(ctx, post, req.TagIDs); err != nil {
 			return err
 		}
 
+		// 7. 指定读者（null 表示不修改）
+		if req.ViewerIDs != nil {
+			if err := repoTx.ReplaceViewers(ctx, post.ID, req.ViewerIDs); err != nil {
+				return err
+			}
+		}
+
+		// 8. 记录状态变更
+		for i := range transitions {
+			transitions[i].PostId, transitions[i].UserId = post.ID, uid
+			if err := repoTx.AddTransition(ctx, &transitions[i]); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	})
 
 	if err != nil {
 		return nil, err
 	}
+	// 标签随更新整体替换，相关推荐需重新计算
+	s.invalidateRelated()
+	if req.Content != nil {
+		s.syncMentions(ctx, post)
+	} else {
+		s.publishMentions(ctx, post)
+	}
+	s.notifyReview(ctx, uid, post, fromStatus)
 	return post, nil
 }
 
-// DeletePost 删除文章：仅作者本人可删
+// checkSpam 检测文章标题与正文，审核者（具备发布权限的角色）跳过检测。
+func (s *PostService) checkSpam(ctx context.Context, uid uint, role, title, content string) error {
+	if s.Spam == nil || s.Workflow.IsReviewer(role) {
+		return nil
+	}
+	res, err := s.Spam.Check(ctx, &spam.Content{
+		Kind:   spam.KindPost,
+		UserID: uid,
+		Title:  title,
+		Body:   content,
+	})
+	if err != nil {
+		return err
+	}
+	if res.Spam {
+		log.Printf("post by user %d rejected as spam by %s: %s", uid, res.Checker, res.Reason)
+		return ErrSpamDetected
+	}
+	return nil
+}
+
+// RelatedPosts 返回与文章相关的已发布文章（“猜你喜欢”）。
+func (s *PostService) RelatedPosts(ctx context.Context, uid, id uint, password string, limit int) ([]dto.RelatedPostResp, error) {
+	return s.Related.Related(ctx, uid, id, password, limit)
+}
+
+// syncMentions 按文章正文更新提及记录（归属于所有者），已发布的公开文章通知被提及者。
+// 文章已保存成功，提及处理失败只记录日志。
+func (s *PostService) syncMentions(ctx context.Context, post *model.Post) {
+	if s.Mentions == nil {
+		return
+	}
+	refs, err := s.Mentions.Sync(ctx, model.MentionTargetPost, post.ID, post.UserID, post.Content, mentionsPublic(post))
+	if err != nil {
+		log.Printf("sync mentions for post %d error: %v", post.ID, err)
+		return
+	}
+	post.Mentions = refs
+}
+
+// publishMentions 文章变为可通知状态（发布或取消私密）时，通知此前未通知的被提及者。
+func (s *PostService) publishMentions(ctx context.Context, post *model.Post) {
+	if s.Mentions == nil || !mentionsPublic(post) {
+		return
+	}
+	if err := s.Mentions.Publish(ctx, model.MentionTargetPost, post.ID); err != nil {
+		log.Printf("publish mentions for post %d error: %v", post.ID, err)
+	}
+}
+
+// attachMentions 填充文章正文中的提及实体；正文被隐藏（密码保护）的文章不返回提及。
+func (s *PostService) attachMentions(ctx context.Context, posts ...*model.Post) error {
+	if s.Mentions == nil || len(posts) == 0 {
+		return nil
+	}
+	ids := make([]uint, 0, len(posts))
+	for _, p := range posts {
+		ids = append(ids, p.ID)
+	}
+	refs, err := s.Mentions.Refs(ctx, model.MentionTargetPost, ids)
+	if err != nil {
+		return err
+	}
+	for _, p := range posts {
+		if p.Content != "" {
+			p.Mentions = refs[p.ID]
+		}
+	}
+	return nil
+}
+
+func (s *PostService) invalidateRelated() {
+	if s.Related != nil {
+		s.Related.Invalidate()
+	}
+}
+
+// DeletePost 删除文章（移入回收站）：仅所有者可删，合著者无权删除
 func (s *PostService) DeletePost(ctx context.Context, uid, id uint) error {
-	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		repoTx := s.Repo.WithDB(tx)
 
 		post, err := repoTx.FindByID(ctx, id)
@@ -162,9 +425,127 @@ func (s *PostService) DeletePost(ctx context.Context, uid, id uint) error {
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	s.invalidateRelated()
+	return nil
+}
+
+// ListPosts 列表查询：复用 Repo 的过滤逻辑，只返回当前用户可见的文章，并填充表态信息
+func (s *PostService) ListPosts(ctx context.Context, uid uint, f repository.PostFilter) ([]model.Post, int64, error) {
+	f.ViewerID = &uid
+	posts, total, err := s.Repo.ListPosts(ctx, f)
+	if err != nil {
+		return nil, 0, err
+	}
+	maskProtected(uid, postPtrs(posts)...)
+	if err := s.Reactions.AttachPostReactions(ctx, uid, postPtrs(posts)...); err != nil {
+		return nil, 0, err
+	}
+	if err := s.attachMentions(ctx, postPtrs(posts)...); err != nil {
+		return nil, 0, err
+	}
+	return posts, total, nil
+}
+
+// ListAuthors 返回文章全部作者（所有者在前）；文章须对 uid 可读，未发布文章仅作者可查看。
+func (s *PostService) ListAuthors(ctx context.Context, uid, postID uint, password string) ([]model.PostAuthor, error) {
+	post, err := s.Repo.FindByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	if err := authorizePostInteract(ctx, s.Repo, post, uid, password); err != nil {
+		return nil, err
+	}
+	return s.Repo.ListAuthors(ctx, postID)
 }
 
-// ListPosts 列表查询：直接复用 Repo 的过滤逻辑
-func (s *PostService) ListPosts(ctx context.Context, f repository.PostFilter) ([]model.Post, int64, error) {
-	return s.Repo.ListPosts(ctx, f)
+// AddCoAuthor 邀请合著者：仅所有者可操作。
+func (s *PostService) AddCoAuthor(ctx context.Context, uid, postID, targetUserID uint) (*model.PostAuthor, error) {
+	if _, err := s.ownedPost(ctx, uid, postID); err != nil {
+		return nil, err
+	}
+	user, err := s.UserRepo.FindByID(ctx, targetUserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	if _, err := s.Repo.FindAuthor(ctx, postID, targetUserID); err == nil {
+		return nil, ErrAuthorExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	author := &model.PostAuthor{
+		PostId: postID,
+		UserId: targetUserID,
+		Role:   model.PostAuthorCoAuthor,
+	}
+	if err := s.Repo.AddAuthor(ctx, author); err != nil {
+		return nil, err
+	}
+	author.User = user
+	return author, nil
+}
+
+// RemoveCoAuthor 移除合著者：仅所有者可操作，且不能移除所有者本人。
+func (s *PostService) RemoveCoAuthor(ctx context.Context, uid, postID, targetUserID uint) error {
+	post, err := s.ownedPost(ctx, uid, postID)
+	if err != nil {
+		return err
+	}
+	if targetUserID == post.UserID {
+		return ErrForbidden
+	}
+	if _, err := s.Repo.FindAuthor(ctx, postID, targetUserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAuthorNotFound
+		}
+		return err
+	}
+	return s.Repo.RemoveAuthor(ctx, postID, targetUserID)
+}
+
+// ownedPost 查询文章并校验当前用户是所有者。
+func (s *PostService) ownedPost(ctx context.Context, uid, postID uint) (*model.Post, error) {
+	post, err := s.Repo.FindByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	if post.UserID != uid {
+		return nil, ErrForbidden
+	}
+	return post, nil
+}
+
+// isPostAuthor 判断用户是否为文章所有者或合著者。
+func isPostAuthor(ctx context.Context, repo *repository.PostRepository, post *model.Post, uid uint) (bool, error) {
+	if post.UserID == uid {
+		return true, nil
+	}
+	if _, err := repo.FindAuthor(ctx, post.ID, uid); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// postPtrs 返回切片中各元素的指针，便于批量填充非持久化字段。
+func postPtrs(posts []model.Post) []*model.Post {
+	ptrs := make([]*model.Post, len(posts))
+	for i := range posts {
+		ptrs[i] = &posts[i]
+	}
+	return ptrs
 }
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"go-blog/internal/model"
+	"go-blog/internal/repository"
+	"go-blog/internal/util"
+)
+
+// 文章可见性相关错误定义。
+var (
+	ErrPostPasswordRequired = errors.New("post password required")
+	ErrPostPasswordMissing  = errors.New("password required for protected post")
+)
+
+// authorizePostRead 校验 uid 能否阅读文章：作者始终可读；私密文章仅指定读者可读，
+// 对其他人表现为不存在；密码保护文章需提供正确密码；不公开文章凭链接即可阅读。
+func authorizePostRead(ctx context.Context, repo *repository.PostRepository, post *model.Post, uid uint, password string) error {
+	switch post.Visibility {
+	case model.PostVisibilityPrivate, model.PostVisibilityPassword:
+	default:
+		return nil
+	}
+	ok, err := isPostAuthor(ctx, repo, post, uid)
+	if err != nil || ok {
+		return err
+	}
+	if post.Visibility == model.PostVisibilityPrivate {
+		viewer, err := repo.IsViewer(ctx, post.ID, uid)
+		if err != nil {
+			return err
+		}
+		if !viewer {
+			return ErrPostNotFound
+		}
+		return nil
+	}
+	if password == "" || !util.CheckPassword(post.PasswordHash, password) {
+		return ErrPostPasswordRequired
+	}
+	return nil
+}
+
+// authorizePostInteract 校验 uid 能否与文章互动（评论、表态、收藏）：未发布文章仅作者可操作，
+// 对其他人表现为不存在；其余规则同 authorizePostRead。
+func authorizePostInteract(ctx context.Context, repo *repository.PostRepository, post *model.Post, uid uint, password string) error {
+	if post.Status != model.PostStatusPublished {
+		ok, err := isPostAuthor(ctx, repo, post, uid)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrPostNotFound
+		}
+	}
+	return authorizePostRead(ctx, repo, post, uid, password)
+}
+
+// maskProtected 对非作者隐藏密码保护文章的正文（用于列表）。
+func maskProtected(uid uint, posts ...*model.Post) {
+	for _, p := range posts {
+		if p.Visibility == model.PostVisibilityPassword && !listedAuthor(p, uid) {
+			p.Content = ""
+		}
+	}
+}
+
+// listedAuthor 根据已预加载的作者列表判断 uid 是否为文章作者。
+func listedAuthor(post *model.Post, uid uint) bool {
+	if post.UserID == uid {
+		return true
+	}
+	for _, a := range post.Authors {
+		if a.UserId == uid {
+			return true
+		}
+	}
+	return false
+}
+
+// isListedVisibility 判断文章是否会出现在公开列表中（公开或密码保护）。
+func isListedVisibility(post model.Post) bool {
+	return post.Visibility == model.PostVisibilityPublic || post.Visibility == model.PostVisibilityPassword
+}
+
+// applyVisibility 根据请求设置文章可见性与访问密码；设为密码保护时必须已有或提供密码。
+func applyVisibility(post *model.Post, visibility *string, password *string) error {
+	if visibility != nil {
+		post.Visibility = *visibility
+	}
+	if post.Visibility == "" {
+		post.Visibility = model.PostVisibilityPublic
+	}
+	if post.Visibility != model.PostVisibilityPassword {
+		post.PasswordHash = ""
+		return nil
+	}
+	if password != nil && *password != "" {
+		hash, err := util.HashPassword(*password)
+		if err != nil {
+			return err
+		}
+		post.PasswordHash = hash
+	}
+	if post.PasswordHash == "" {
+		return ErrPostPasswordMissing
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go-blog/internal/model"
+	"go-blog/internal/repository"
+	"go-blog/internal/util"
+	"gorm.io/gorm"
+)
+
+// 审核流程相关错误定义。
+var (
+	ErrInvalidStatus         = errors.New("invalid post status")
+	ErrTransitionNotAllowed  = errors.New("status transition not allowed")
+	ErrReviewCommentRequired = errors.New("review comment required")
+)
+
+// Workflow 文章状态流转规则。启用审核流程时，没有发布权限的作者需经
+// draft → in_review → approved → published；审核者（具备发布权限的角色）可批准或退回修改。
+// 未启用时仅允许 draft 与 published 之间切换。
+type Workflow struct {
+	Enabled       bool
+	ReviewerRoles []string
+}
+
+// LoadWorkflow 从环境变量读取审核流程配置：EDITORIAL_WORKFLOW、EDITORIAL_REVIEWER_ROLES。
+func LoadWorkflow() Workflow {
+	var roles []string
+	for _, r := range strings.Split(util.EnvString("EDITORIAL_REVIEWER_ROLES", "admin,editor"), ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			roles = append(roles, r)
+		}
+	}
+	return Workflow{
+		Enabled:       util.EnvBool("EDITORIAL_WORKFLOW", false),
+		ReviewerRoles: roles,
+	}
+}
+
+// IsReviewer 判断角色是否具备审核与直接发布权限。
+func (w Workflow) IsReviewer(role string) bool {
+	for _, r := range w.ReviewerRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// validStatus 判断状态值在当前配置下是否可用。
+func (w Workflow) validStatus(status string) bool {
+	switch status {
+	case model.PostStatusDraft, model.PostStatusPublished:
+		return true
+	case model.PostStatusInReview, model.PostStatusApproved:
+		return w.Enabled
+	}
+	return false
+}
+
+// CheckInitial 校验新建文章的初始状态。
+func (w Workflow) CheckInitial(status, role string) error {
+	if !w.validStatus(status) {
+		return ErrInvalidStatus
+	}
+	switch status {
+	case model.PostStatusApproved:
+		return ErrTransitionNotAllowed
+	case model.PostStatusPublished:
+		if w.Enabled && !w.IsReviewer(role) {
+			return ErrTransitionNotAllowed
+		}
+	}
+	return nil
+}
+
+// CheckTransition 校验状态变更：isAuthor 表示操作人是文章作者，role 为其系统角色。
+func (w Workflow) CheckTransition(from, to, role string, isAuthor bool, comment string) error {
+	if !w.validStatus(to) {
+		return ErrInvalidStatus
+	}
+	reviewer := w.IsReviewer(role)
+	if !isAuthor && !(w.Enabled && reviewer) {
+		return ErrForbidden
+	}
+	if from == to {
+		return nil
+	}
+	if !w.Enabled {
+		return nil
+	}
+
+	// 审核者可直接发布或下线
+	if reviewer && (to == model.PostStatusPublished || to == model.PostStatusDraft) {
+		if from == model.PostStatusInReview && to == model.PostStatusDraft && !isAuthor && comment == "" {
+			return ErrReviewCommentRequired
+		}
+		return nil
+	}
+
+	switch {
+	case from == model.PostStatusDraft && to == model.PostStatusInReview:
+		return nil // 作者提交审核
+	case from == model.PostStatusInReview && to == model.PostStatusApproved:
+		if reviewer {
+			return nil
+		}
+	case from == model.PostStatusInReview && to == model.PostStatusDraft:
+		return nil // 作者撤回
+	case from == model.PostStatusApproved && to == model.PostStatusPublished:
+		return nil // 作者发布已批准的文章
+	case from == model.PostStatusApproved && to == model.PostStatusDraft,
+		from == model.PostStatusPublished && to == model.PostStatusDraft:
+		return nil
+	}
+	return ErrTransitionNotAllowed
+}
+
+// RequiresReReview 判断 role 修改文章标题或正文后是否需要重新审核：启用审核流程时，
+// 非审核者改动已批准或已发布的文章须退回 in_review，避免未经审核的内容被发布或继续公开。
+func (w Workflow) RequiresReReview(status, role string) bool {
+	if !w.Enabled || w.IsReviewer(role) {
+		return false
+	}
+	return status == model.PostStatusApproved || status == model.PostStatusPublished
+}
+
+// TransitionPost 变更文章状态并记录流转：作者提交/撤回/发布，审核者批准或退回修改（需填写意见）。
+func (s *PostService) TransitionPost(ctx context.Context, uid uint, role string, id uint, to, comment string) (*model.Post, error) {
+	var post *model.Post
+	var from string
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		repoTx := s.Repo.WithDB(tx)
+
+		p, err := repoTx.FindByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrPostNotFound
+			}
+			return err
+		}
+		post = p
+
+		isAuthor, err := isPostAuthor(ctx, repoTx, post, uid)
+		if err != nil {
+			return err
+		}
+		from = post.Status
+		if err := s.Workflow.CheckTransition(from, to, role, isAuthor, comment); err != nil {
+			return err
+		}
+		if from == to {
+			return nil
+		}
+
+		post.Status = to
+		updated, current, err := repoTx.UpdateIfVersion(ctx, post, post.Version)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return &PostConflictError{Current: current}
+		}
+		return repoTx.AddTransition(ctx, &model.PostTransition{
+			PostId:     post.ID,
+			UserId:     uid,
+			FromStatus: from,
+			ToStatus:   to,
+			Comment:    comment,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publishMentions(ctx, post)
+	s.notifyReview(ctx, uid, post, from)
+	return post, nil
+}
+
+// notifyReview 审核中的文章被批准、退回修改或直接发布时通知所有者（所有者自己操作的不通知）。
+func (s *PostService) notifyReview(ctx context.Context, uid uint, post *model.Post, from string) {
+	if uid == post.UserID || from != model.PostStatusInReview || post.Status == from {
+		return
+	}
+	s.Notifier.Notify(ctx, NotificationEvent{
+		Type:       model.NotificationModeration,
+		UserId:     post.UserID,
+		TargetType: model.MentionTargetPost,
+		TargetId:   post.ID,
+		PostId:     post.ID,
+		Detail:     post.Status,
+	})
+}
+
+// ListTransitions 返回文章的状态流转记录，作者与审核者可查看。
+func (s *PostService) ListTransitions(ctx context.Context, uid uint, role string, id uint) ([]model.PostTransition, error) {
+	post, err := s.Repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	if !s.Workflow.IsReviewer(role) {
+		ok, err := isPostAuthor(ctx, s.Repo, post, uid)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, ErrForbidden
+		}
+	}
+	return s.Repo.ListTransitions(ctx, id)
+}
+
+// ListReviewQueue 分页返回待审核的文章（审核者使用）。
+func (s *PostService) ListReviewQueue(ctx context.Context, page, pageSize int) ([]model.Post, int64, error) {
+	status := model.PostStatusInReview
+	return s.Repo.ListPosts(ctx, repository.PostFilter{
+		Status:   &status,
+		Order:    "latest",
+		Page:     page,
+		PageSize: pageSize,
+	})
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-blog/internal/dto"
+	"go-blog/internal/model"
+	"go-blog/internal/repository"
+	"go-blog/internal/util"
+	"gorm.io/gorm"
+)
+
+// 预览链接相关错误定义。
+var (
+	ErrPreviewNotFound  = errors.New("preview link not found")
+	ErrPreviewPublished = errors.New("post already published")
+	ErrPreviewInvalid   = errors.New("invalid or expired preview token")
+)
+
+// PreviewPath 草稿预览的公开访问路径前缀。
+const PreviewPath = "/api/shared/previews/"
+
+// PreviewService 处理草稿预览链接的生成、列出、撤销与访问。
+type PreviewService struct {
+	repo       *repository.PreviewRepository
+	postRepo   *repository.PostRepository
+	defaultTTL time.Duration
+}
+
+// NewPreviewService 构造预览服务，默认有效期由 PREVIEW_TTL_HOURS 配置。
+func NewPreviewService(repo *repository.PreviewRepository, postRepo *repository.PostRepository) *PreviewService {
+	return &PreviewService{
+		repo:       repo,
+		postRepo:   postRepo,
+		defaultTTL: time.Duration(util.EnvInt("PREVIEW_TTL_HOURS", 72)) * time.Hour,
+	}
+}
+
+// CreatePreview 为未发布的文章生成预览链接，仅作者（所有者或合著者）可操作。
+func (s *PreviewService) CreatePreview(ctx context.Context, uid, postID uint, req dto.CreatePreviewReq) (*dto.PreviewLinkResp, error) {
+	post, err := s.authorPost(ctx, uid, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.Status == "published" {
+		return nil, ErrPreviewPublished
+	}
+
+	jti, err := util.RandomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	ttl := s.defaultTTL
+	if req.ExpiresInHours > 0 {
+		ttl = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+	// 令牌中的时间精度为秒，落库前截断以便后续重新签出同一令牌
+	now := time.Now().Truncate(time.Second)
+	link := &model.PreviewLink{
+		PostId:    postID,
+		UserId:    uid,
+		TokenId:   jti,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+	if err := s.repo.Create(ctx, link); err != nil {
+		return nil, err
+	}
+	return toPreviewLinkResp(*link)
+}
+
+// ListPreviews 列出文章当前有效的预览链接。
+func (s *PreviewService) ListPreviews(ctx context.Context, uid, postID uint) ([]dto.PreviewLinkResp, error) {
+	if _, err := s.authorPost(ctx, uid, postID); err != nil {
+		return nil, err
+	}
+	links, err := s.repo.ListActive(ctx, postID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]dto.PreviewLinkResp, 0, len(links))
+	for _, l := range links {
+		item, err := toPreviewLinkResp(l)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, *item)
+	}
+	return resp, nil
+}
+
+// RevokePreview 撤销预览链接，撤销后令牌立即失效。
+func (s *PreviewService) RevokePreview(ctx context.Context, uid, postID, previewID uint) error {
+	if _, err := s.authorPost(ctx, uid, postID); err != nil {
+		return err
+	}
+	link, err := s.repo.FindByID(ctx, previewID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPreviewNotFound
+		}
+		return err
+	}
+	if link.PostId != postID {
+		return ErrPreviewNotFound
+	}
+	return s.repo.Revoke(ctx, link.Id, time.Now())
+}
+
+// OpenPreview 凭预览令牌只读访问文章：校验签名、过期与撤销状态。
+func (s *PreviewService) OpenPreview(ctx context.Context, token string) (*dto.PreviewPostResp, error) {
+	postID, jti, err := util.ParsePreviewToken(token)
+	if err != nil {
+		return nil, ErrPreviewInvalid
+	}
+	link, err := s.repo.FindByTokenID(ctx, jti)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPreviewInvalid
+		}
+		return nil, err
+	}
+	if link.PostId != postID || link.RevokedAt != nil || !time.Now().Before(link.ExpiresAt) {
+		return nil, ErrPreviewInvalid
+	}
+	post, err := s.postRepo.FindDetailByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	return toPreviewPostResp(post), nil
+}
+
+// authorPost 查询文章并校验当前用户为作者。
+func (s *PreviewService) authorPost(ctx context.Context, uid, postID uint) (*model.Post, error) {
+	post, err := s.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	ok, err := isPostAuthor(ctx, s.postRepo, post, uid)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrForbidden
+	}
+	return post, nil
+}
+
+func toPreviewLinkResp(l model.PreviewLink) (*dto.PreviewLinkResp, error) {
+	token, err := util.GeneratePreviewToken(l.PostId, l.TokenId, l.CreatedAt, l.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.PreviewLinkResp{
+		Id:        l.Id,
+		PostId:    l.PostId,
+		Token:     token,
+		URL:       PreviewPath + token,
+		ExpiresAt: l.ExpiresAt,
+		CreatedAt: l.CreatedAt,
+	}, nil
+}
+
+// toPreviewPostResp 只暴露预览需要的字段，作者仅返回 id 与用户名。
+func toPreviewPostResp(p *model.Post) *dto.PreviewPostResp {
+	resp := &dto.PreviewPostResp{
+		Id:        p.ID,
+		Title:     p.Title,
+		Content:   p.Content,
+		Tags:      make([]dto.TagResp, 0, len(p.Tags)),
+		Authors:   make([]dto.UserBrief, 0, len(p.Authors)+1),
+		UpdatedAt: p.UpdatedAt,
+	}
+	if p.Category.Id != 0 {
+		resp.Category = &dto.CategoryResp{
+			Id:       p.Category.Id,
+			Name:     p.Category.Name,
+			Slug:     p.Category.Slug,
+			ParentId: p.Category.ParentId,
+		}
+	}
+	for _, t := range p.Tags {
+		resp.Tags = append(resp.Tags, dto.TagResp{Id: t.Id, Name: t.Name, Slug: t.Slug, Weight: t.Weight})
+	}
+	if p.User != nil {
+		resp.Authors = append(resp.Authors, dto.UserBrief{Id: p.User.ID, Username: p.User.Username})
+	}
+	for _, a := range p.Authors {
+		if a.User == nil || a.UserId == p.UserID {
+			continue
+		}
+		resp.Authors = append(resp.Authors, dto.UserBrief{Id: a.User.ID, Username: a.User.Username})
+	}
+	return resp
+}
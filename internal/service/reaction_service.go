@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go-blog/internal/dto"
+	"go-blog/internal/model"
+	"go-blog/internal/repository"
+	"go-blog/internal/util"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidReaction 表示表情不在允许的集合中。
+var ErrInvalidReaction = errors.New("invalid reaction")
+
+// defaultReactionEmojis 默认允许的表态集合，可通过 REACTION_EMOJIS 覆盖（逗号分隔）。
+const defaultReactionEmojis = "like,love,laugh,wow,sad,angry"
+
+// ReactionService 处理文章与评论的点赞/表情表态。
+type ReactionService struct {
+	repo        *repository.ReactionRepository
+	postRepo    *repository.PostRepository
+	commentRepo *repository.CommentRepository
+	notifier    *NotificationService
+	emojis      []string
+	allowed     map[string]struct{}
+}
+
+// NewReactionService 构造表态服务，"like" 始终包含在允许集合中；notifier 可为 nil。
+func NewReactionService(repo *repository.ReactionRepository, postRepo *repository.PostRepository, commentRepo *repository.CommentRepository, notifier *NotificationService) *ReactionService {
+	s := &ReactionService{
+		repo:        repo,
+		postRepo:    postRepo,
+		commentRepo: commentRepo,
+		notifier:    notifier,
+		allowed:     make(map[string]struct{}),
+	}
+	for _, e := range strings.Split("like,"+util.EnvString("REACTION_EMOJIS", defaultReactionEmojis), ",") {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		if _, ok := s.allowed[e]; ok {
+			continue
+		}
+		s.allowed[e] = struct{}{}
+		s.emojis = append(s.emojis, e)
+	}
+	return s
+}
+
+// Emojis 返回允许的表态集合。
+func (s *ReactionService) Emojis() []string {
+	return s.emojis
+}
+
+// TogglePostReaction 切换当前用户对文章的表态，文章须对当前用户可读（密码保护文章需提供 password）。
+func (s *ReactionService) TogglePostReaction(ctx context.Context, uid, postID uint, password, emoji string) (*dto.ReactionResp, error) {
+	post, err := s.readablePost(ctx, uid, postID, password)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.toggle(ctx, uid, model.ReactionTargetPost, postID, emoji)
+	if err == nil && resp.Active {
+		s.notifyReaction(ctx, uid, post.UserID, model.ReactionTargetPost, postID, postID, emoji)
+	}
+	return resp, err
+}
+
+// ToggleCommentReaction 切换当前用户对评论的表态，评论所在文章须对当前用户可读。
+func (s *ReactionService) ToggleCommentReaction(ctx context.Context, uid, commentID uint, password, emoji string) (*dto.ReactionResp, error) {
+	comment, err := s.commentRepo.FindByID(ctx, commentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	if comment.Tombstone || !comment.VisibleTo(uid) {
+		return nil, ErrCommentNotFound
+	}
+	if _, err := s.readablePost(ctx, uid, comment.PostId, password); err != nil {
+		if errors.Is(err, ErrPostNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	resp, err := s.toggle(ctx, uid, model.ReactionTargetComment, commentID, emoji)
+	if err == nil && resp.Active {
+		s.notifyReaction(ctx, uid, comment.UserId, model.ReactionTargetComment, commentID, comment.PostId, emoji)
+	}
+	return resp, err
+}
+
+// readablePost 查询文章并校验当前用户可以对其表态（规则同 authorizePostInteract）。
+func (s *ReactionService) readablePost(ctx context.Context, uid, postID uint, password string) (*model.Post, error) {
+	post, err := s.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	if err := authorizePostInteract(ctx, s.postRepo, post, uid, password); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// notifyReaction 通知目标作者收到新表态，同一目标的未读表态通知合并为一条（取消表态不撤回通知）。
+func (s *ReactionService) notifyReaction(ctx context.Context, uid, owner uint, targetType string, targetID, postID uint, emoji string) {
+	s.notifier.Notify(ctx, NotificationEvent{
+		Type:       model.NotificationReaction,
+		UserId:     owner,
+		ActorId:    uid,
+		TargetType: targetType,
+		TargetId:   targetID,
+		PostId:     postID,
+		Detail:     emoji,
+	})
+}
+
+func (s *ReactionService) toggle(ctx context.Context, uid uint, targetType string, targetID uint, emoji string) (*dto.ReactionResp, error) {
+	if _, ok := s.allowed[emoji]; !ok {
+		return nil, ErrInvalidReaction
+	}
+	active, err := s.repo.Toggle(ctx, uid, targetType, targetID, emoji)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.repo.CountsByTargets(ctx, targetType, []uint{targetID})
+	if err != nil {
+		return nil, err
+	}
+	mine, err := s.repo.UserReactions(ctx, uid, targetType, []uint{targetID})
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ReactionResp{
+		Emoji:       emoji,
+		Active:      active,
+		Reactions:   counts[targetID],
+		MyReactions: mine[targetID],
+	}, nil
+}
+
+// AttachPostReactions 为文章填充表态计数与当前用户的表态。
+func (s *ReactionService) AttachPostReactions(ctx context.Context, uid uint, posts ...*model.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+	ids := make([]uint, 0, len(posts))
+	for _, p := range posts {
+		ids = append(ids, p.ID)
+	}
+	counts, err := s.repo.CountsByTargets(ctx, model.ReactionTargetPost, ids)
+	if err != nil {
+		return err
+	}
+	mine, err := s.repo.UserReactions(ctx, uid, model.ReactionTargetPost, ids)
+	if err != nil {
+		return err
+	}
+	for _, p := range posts {
+		p.Reactions = counts[p.ID]
+		p.MyReactions = mine[p.ID]
+	}
+	return nil
+}
+
+// CommentReactions 批量查询评论的表态计数与当前用户的表态。
+func (s *ReactionService) CommentReactions(ctx context.Context, uid uint, ids []uint) (map[uint]map[string]int64, map[uint][]string, error) {
+	counts, err := s.repo.CountsByTargets(ctx, model.ReactionTargetComment, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	mine, err := s.repo.UserReactions(ctx, uid, model.ReactionTargetComment, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	return counts, mine, nil
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"go-blog/internal/dto"
+	"go-blog/internal/model"
+	"go-blog/internal/repository"
+	"go-blog/internal/spam"
+	"go-blog/internal/util"
+	"gorm.io/gorm"
+)
+
+// relatedCacheSize 每篇文章缓存的推荐条数（请求的 limit 不超过该值）。
+const relatedCacheSize = 20
+
+// RelatedWeights 相关文章打分权重。
+type RelatedWeights struct {
+	Tag      float64 // 每个共同标签的基础分（再乘以 1+Tag.Weight）
+	Category float64 // 同分类得满分，祖先分类得一半
+	Recency  float64 // 越新越高，按 30 天半衰
+	Terms    float64 // 标题与正文词项的 Jaccard 相似度
+}
+
+// LoadRelatedWeights 从环境变量读取相关文章权重。
+func LoadRelatedWeights() RelatedWeights {
+	return RelatedWeights{
+		Tag:      util.EnvFloat("RELATED_WEIGHT_TAG", 3),
+		Category: util.EnvFloat("RELATED_WEIGHT_CATEGORY", 2),
+		Recency:  util.EnvFloat("RELATED_WEIGHT_RECENCY", 1),
+		Terms:    util.EnvFloat("RELATED_WEIGHT_TERMS", 2),
+	}
+}
+
+type relatedEntry struct {
+	items []dto.RelatedPostResp
+	at    time.Time
+}
+
+// RelatedService 计算“相关文章”推荐，结果按文章缓存；任意文章标签变化时整体失效。
+type RelatedService struct {
+	postRepo     *repository.PostRepository
+	categoryRepo *repository.CategoryRepository
+	weights      RelatedWeights
+	useTerms     bool
+	ttl          time.Duration
+
+	mu    sync.RWMutex
+	cache map[uint]relatedEntry
+}
+
+// NewRelatedService 构造相关文章服务：RELATED_TERM_SIMILARITY 开启词项相似度，RELATED_CACHE_TTL 为缓存分钟数。
+func NewRelatedService(postRepo *repository.PostRepository, categoryRepo *repository.CategoryRepository) *RelatedService {
+	return &RelatedService{
+		postRepo:     postRepo,
+		categoryRepo: categoryRepo,
+		weights:      LoadRelatedWeights(),
+		useTerms:     util.EnvBool("RELATED_TERM_SIMILARITY", false),
+		ttl:          util.EnvMinutes("RELATED_CACHE_TTL", 10),
+		cache:        make(map[uint]relatedEntry),
+	}
+}
+
+// Invalidate 清空推荐缓存（文章标签变化、移入回收站、恢复或永久删除时调用）。
+func (s *RelatedService) Invalidate() {
+	s.mu.Lock()
+	s.cache = make(map[uint]relatedEntry)
+	s.mu.Unlock()
+}
+
+// Related 返回与文章最相关的 limit 篇已发布文章；来源文章需对当前用户可见。
+func (s *RelatedService) Related(ctx context.Context, uid, postID uint, password string, limit int) ([]dto.RelatedPostResp, error) {
+	post, err := s.postRepo.FindDetailByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	if err := authorizePostRead(ctx, s.postRepo, post, uid, password); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > relatedCacheSize {
+		limit = 5
+	}
+
+	s.mu.RLock()
+	entry, ok := s.cache[postID]
+	s.mu.RUnlock()
+	if !ok || time.Since(entry.at) > s.ttl {
+		items, err := s.compute(ctx, post)
+		if err != nil {
+			return nil, err
+		}
+		entry = relatedEntry{items: items, at: time.Now()}
+		s.mu.Lock()
+		s.cache[postID] = entry
+		s.mu.Unlock()
+	}
+
+	if len(entry.items) > limit {
+		return entry.items[:limit], nil
+	}
+	return entry.items, nil
+}
+
+// compute 对候选文章打分并返回前 relatedCacheSize 篇。
+func (s *RelatedService) compute(ctx context.Context, post *model.Post) ([]dto.RelatedPostResp, error) {
+	categories, err := s.categoryRepo.ListOrdered(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ancestors := categoryAncestors(categories, post.CategoryId)
+	categoryIDs := append([]uint{post.CategoryId}, ancestors...)
+
+	tagIDs := make([]uint, 0, len(post.Tags))
+	tagWeight := make(map[uint]int, len(post.Tags))
+	for _, t := range post.Tags {
+		tagIDs = append(tagIDs, t.Id)
+		tagWeight[t.Id] = t.Weight
+	}
+
+	candidates, err := s.postRepo.ListRelatedCandidates(ctx, post.ID, tagIDs, categoryIDs, 200)
+	if err != nil {
+		return nil, err
+	}
+
+	var sourceTerms map[string]struct{}
+	if s.useTerms {
+		sourceTerms = terms(post.Title + " " + post.Content)
+	}
+	ancestorSet := make(map[uint]struct{}, len(ancestors))
+	for _, id := range ancestors {
+		ancestorSet[id] = struct{}{}
+	}
+
+	now := time.Now()
+	items := make([]dto.RelatedPostResp, 0, len(candidates))
+	for _, c := range candidates {
+		score := 0.0
+		for _, t := range c.Tags {
+			if w, ok := tagWeight[t.Id]; ok {
+				score += s.weights.Tag * float64(1+w)
+			}
+		}
+		if c.CategoryId == post.CategoryId {
+			score += s.weights.Category
+		} else if _, ok := ancestorSet[c.CategoryId]; ok {
+			score += s.weights.Category / 2
+		}
+		ageDays := now.Sub(c.CreatedAt).Hours() / 24
+		if ageDays < 0 {
+			ageDays = 0
+		}
+		score += s.weights.Recency * math.Pow(0.5, ageDays/30)
+		if s.useTerms {
+			score += s.weights.Terms * jaccard(sourceTerms, terms(c.Title+" "+c.Content))
+		}
+
+		item := dto.RelatedPostResp{
+			Id:         c.ID,
+			Title:      c.Title,
+			CategoryId: c.CategoryId,
+			Score:      math.Round(score*1000) / 1000,
+			CreatedAt:  c.CreatedAt,
+		}
+		if c.User != nil {
+			item.User = dto.UserBrief{Id: c.User.ID, Username: c.User.Username}
+		}
+		items = append(items, item)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Score != items[j].Score {
+			return items[i].Score > items[j].Score
+		}
+		return items[i].Id > items[j].Id
+	})
+	if len(items) > relatedCacheSize {
+		items = items[:relatedCacheSize]
+	}
+	return items, nil
+}
+
+// categoryAncestors 返回分类的全部祖先ID（由近及远），遇到环时停止。
+func categoryAncestors(categories []model.Category, id uint) []uint {
+	parent := make(map[uint]*uint, len(categories))
+	for _, c := range categories {
+		parent[c.Id] = c.ParentId
+	}
+	var out []uint
+	seen := map[uint]bool{id: true}
+	for p := parent[id]; p != nil && !seen[*p]; p = parent[*p] {
+		seen[*p] = true
+		out = append(out, *p)
+	}
+	return out
+}
+
+// terms 提取词项集合，切分规则与垃圾检测共用 spam.TokenizeN（不限数量）。
+func terms(text string) map[string]struct{} {
+	tokens := spam.TokenizeN(text, 0)
+	out := make(map[string]struct{}, len(tokens))
+	for _, t := range tokens {
+		out[t] = struct{}{}
+	}
+	return out
+}
+
+// jaccard 计算两个词项集合的 Jaccard 相似度。
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	inter := 0
+	for t := range a {
+		if _, ok := b[t]; ok {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(a)+len(b)-inter)
+}
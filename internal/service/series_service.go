@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"go-blog/internal/dto"
+	"go-blog/internal/model"
+	"go-blog/internal/repository"
+	"gorm.io/gorm"
+)
+
+// 系列相关错误定义。
+var (
+	ErrSeriesNotFound    = errors.New("series not found")
+	ErrSeriesForbidden   = errors.New("series forbidden")
+	ErrSeriesInvalidPost = errors.New("invalid series post")
+	ErrSeriesPostTaken   = errors.New("post already in another series")
+)
+
+// seriesPartTrashed 回收站中篇目的状态标记。
+const seriesPartTrashed = "trash"
+
+// SeriesService 处理文章系列的创建、排序与导航。
+type SeriesService struct {
+	db       *gorm.DB
+	repo     *repository.SeriesRepository
+	postRepo *repository.PostRepository
+}
+
+// NewSeriesService 构造系列服务。
+func NewSeriesService(db *gorm.DB, repo *repository.SeriesRepository, postRepo *repository.PostRepository) *SeriesService {
+	return &SeriesService{db: db, repo: repo, postRepo: postRepo}
+}
+
+// CreateSeries 创建系列，可同时按顺序指定文章；系列与篇目在同一事务中写入。
+func (s *SeriesService) CreateSeries(ctx context.Context, uid uint, req dto.CreateSeriesReq) (*model.Series, error) {
+	if err := s.validatePosts(ctx, uid, 0, req.PostIds); err != nil {
+		return nil, err
+	}
+	series := &model.Series{
+		UserId:      uid,
+		Title:       req.Title,
+		Description: req.Description,
+	}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		repoTx := s.repo.WithDB(tx)
+		if err := repoTx.Create(ctx, series); err != nil {
+			return err
+		}
+		if len(req.PostIds) > 0 {
+			return repoTx.ReplaceParts(ctx, series.Id, req.PostIds)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// UpdateSeries 更新系列标题与简介，仅作者可操作。
+func (s *SeriesService) UpdateSeries(ctx context.Context, uid, id uint, req dto.UpdateSeriesReq) (*model.Series, error) {
+	series, err := s.ownedSeries(ctx, uid, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Title != nil {
+		series.Title = *req.Title
+	}
+	if req.Description != nil {
+		series.Description = *req.Description
+	}
+	if err := s.repo.Save(ctx, series); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// SetSeriesPosts 按给定顺序设置系列文章，用于添加、移除与重排。
+func (s *SeriesService) SetSeriesPosts(ctx context.Context, uid, id uint, postIDs []uint) error {
+	if _, err := s.ownedSeries(ctx, uid, id); err != nil {
+		return err
+	}
+	if err := s.validatePosts(ctx, uid, id, postIDs); err != nil {
+		return err
+	}
+	return s.repo.ReplaceParts(ctx, id, postIDs)
+}
+
+// DeleteSeries 删除系列（不删除文章），仅作者可操作。
+func (s *SeriesService) DeleteSeries(ctx context.Context, uid, id uint) error {
+	series, err := s.ownedSeries(ctx, uid, id)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, series)
+}
+
+// GetSeries 查询系列详情；非作者只能看到已发布且公开列出的篇目，回收站中的篇目只对作者单独列出。
+func (s *SeriesService) GetSeries(ctx context.Context, uid, id uint) (*dto.SeriesResp, error) {
+	series, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSeriesNotFound
+		}
+		return nil, err
+	}
+	parts, err := s.repo.ListParts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toSeriesResp(*series)
+	resp.Parts = make([]dto.SeriesPartResp, 0, len(parts))
+	for _, p := range parts {
+		if p.Post.DeletedAt.Valid {
+			if series.UserId == uid {
+				resp.Trashed = append(resp.Trashed, dto.SeriesPartResp{
+					PostId: p.PostId,
+					Title:  p.Post.Title,
+					Status: seriesPartTrashed,
+				})
+			}
+			continue
+		}
+		if series.UserId != uid && (p.Post.Status != "published" || !isListedVisibility(p.Post)) {
+			continue
+		}
+		resp.Parts = append(resp.Parts, dto.SeriesPartResp{
+			Position: len(resp.Parts) + 1,
+			PostId:   p.PostId,
+			Title:    p.Post.Title,
+			Status:   p.Post.Status,
+		})
+	}
+	return &resp, nil
+}
+
+// ListSeries 分页查询系列，可按作者筛选。
+func (s *SeriesService) ListSeries(ctx context.Context, userID *uint, page, pageSize int) ([]dto.SeriesResp, int64, error) {
+	list, total, err := s.repo.List(ctx, repository.SeriesFilter{
+		UserID:   userID,
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	resp := make([]dto.SeriesResp, 0, len(list))
+	for _, series := range list {
+		resp = append(resp, toSeriesResp(series))
+	}
+	return resp, total, nil
+}
+
+// NavForPost 计算文章在系列中的位置及上一篇/下一篇；
+// 只在已发布且公开列出的篇目（以及当前文章本身）之间导航。文章不属于任何系列时返回 nil。
+func (s *SeriesService) NavForPost(ctx context.Context, post *model.Post) (*model.SeriesNav, error) {
+	part, err := s.repo.FindPartByPostID(ctx, post.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	series, err := s.repo.FindByID(ctx, part.SeriesId)
+	if err != nil {
+		return nil, err
+	}
+	parts, err := s.repo.ListParts(ctx, part.SeriesId)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]model.SeriesPart, 0, len(parts))
+	current := -1
+	for _, p := range parts {
+		if p.Post.DeletedAt.Valid {
+			continue
+		}
+		if p.PostId != post.ID && (p.Post.Status != "published" || !isListedVisibility(p.Post)) {
+			continue
+		}
+		if p.PostId == post.ID {
+			current = len(visible)
+		}
+		visible = append(visible, model.SeriesPart{PostId: p.PostId, Title: p.Post.Title})
+	}
+	if current < 0 {
+		return nil, nil
+	}
+
+	nav := &model.SeriesNav{
+		SeriesId: series.Id,
+		Title:    series.Title,
+		Position: current + 1,
+		Total:    len(visible),
+	}
+	if current > 0 {
+		prev := visible[current-1]
+		nav.Prev = &prev
+	}
+	if current < len(visible)-1 {
+		next := visible[current+1]
+		nav.Next = &next
+	}
+	return nav, nil
+}
+
+// validatePosts 校验文章存在、无重复、归属当前作者且未被其他系列占用。
+func (s *SeriesService) validatePosts(ctx context.Context, uid, seriesID uint, postIDs []uint) error {
+	if len(postIDs) == 0 {
+		return nil
+	}
+	seen := make(map[uint]struct{}, len(postIDs))
+	for _, id := range postIDs {
+		if _, ok := seen[id]; ok {
+			return ErrSeriesInvalidPost
+		}
+		seen[id] = struct{}{}
+	}
+
+	posts, err := s.postRepo.FindByIDs(ctx, postIDs)
+	if err != nil {
+		return err
+	}
+	if len(posts) != len(postIDs) {
+		return ErrSeriesInvalidPost
+	}
+	for _, p := range posts {
+		if p.UserID != uid {
+			return ErrSeriesForbidden
+		}
+	}
+
+	taken, err := s.repo.CountOtherSeries(ctx, seriesID, postIDs)
+	if err != nil {
+		return err
+	}
+	if taken > 0 {
+		return ErrSeriesPostTaken
+	}
+	return nil
+}
+
+// ownedSeries 查询系列并校验归属。
+func (s *SeriesService) ownedSeries(ctx context.Context, uid, id uint) (*model.Series, error) {
+	series, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSeriesNotFound
+		}
+		return nil, err
+	}
+	if series.UserId != uid {
+		return nil, ErrSeriesForbidden
+	}
+	return series, nil
+}
+
+func toSeriesResp(series model.Series) dto.SeriesResp {
+	resp := dto.SeriesResp{
+		Id:          series.Id,
+		Title:       series.Title,
+		Description: series.Description,
+		CreatedAt:   series.CreatedAt,
+		UpdatedAt:   series.UpdatedAt,
+	}
+	if series.User != nil {
+		resp.User = dto.UserBrief{Id: series.User.ID, Username: series.User.Username}
+	}
+	return resp
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"go-blog/internal/model"
+	"go-blog/internal/spam"
+	"go-blog/internal/util"
+)
+
+// ErrSpamDetected 内容被判定为垃圾信息（用于文章；评论会进入垃圾状态而不是被拒绝）。
+var ErrSpamDetected = errors.New("content looks like spam")
+
+// SpamCheckers 评论与文章使用的检测链。
+type SpamCheckers struct {
+	Comments spam.SpamChecker
+	Posts    spam.SpamChecker // 未启用 SPAM_CHECK_POSTS 时为 nil
+}
+
+// LoadSpamCheckers 按环境变量组装垃圾内容检测链：
+// SPAM_KEYWORDS、SPAM_BLOCKLIST_FILE、SPAM_MAX_LINKS、SPAM_RATE_WINDOW、SPAM_RATE_MAX、
+// SPAM_BAYES、SPAM_BAYES_THRESHOLD、SPAM_BAYES_MIN_DOCS、AKISMET_KEY、AKISMET_URL、SPAM_CHECK_POSTS。
+// 评论依次经过黑名单、链接数、频率、贝叶斯与 Akismet；文章正文较长、链接较多且会反复保存，
+// 只使用黑名单与 Akismet。
+func LoadSpamCheckers(store spam.TokenStore) SpamCheckers {
+	var comments, posts []spam.SpamChecker
+
+	var keywords, patterns []string
+	for _, k := range strings.Split(util.EnvString("SPAM_KEYWORDS", ""), ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			keywords = append(keywords, k)
+		}
+	}
+	if path := util.EnvString("SPAM_BLOCKLIST_FILE", ""); path != "" {
+		if f, err := os.Open(path); err != nil {
+			log.Printf("open spam blocklist error: %v", err)
+		} else {
+			kw, pt, err := spam.ParseBlocklist(f)
+			f.Close()
+			if err != nil {
+				log.Printf("read spam blocklist error: %v", err)
+			}
+			keywords, patterns = append(keywords, kw...), pt
+		}
+	}
+	if bl, err := spam.NewBlocklist(keywords, patterns); err != nil {
+		log.Printf("spam blocklist error: %v", err)
+	} else if !bl.Empty() {
+		comments = append(comments, bl)
+		posts = append(posts, bl)
+	}
+
+	if n := util.EnvInt("SPAM_MAX_LINKS", 3); n > 0 {
+		comments = append(comments, &spam.LinkLimit{Max: n})
+	}
+	if n := util.EnvInt("SPAM_RATE_MAX", 5); n > 0 {
+		comments = append(comments, spam.NewRateLimit(util.EnvSeconds("SPAM_RATE_WINDOW", 60), n))
+	}
+	if util.EnvBool("SPAM_BAYES", true) && store != nil {
+		comments = append(comments, &spam.Bayes{
+			Store:     store,
+			Threshold: util.EnvFloat("SPAM_BAYES_THRESHOLD", 0.9),
+			MinDocs:   int64(util.EnvInt("SPAM_BAYES_MIN_DOCS", 20)),
+		})
+	}
+	if key := util.EnvString("AKISMET_KEY", ""); key != "" {
+		akismet := spam.NewAkismet(util.EnvString("AKISMET_URL", ""), key, util.EnvString("SITE_BASE_URL", ""))
+		comments = append(comments, akismet)
+		posts = append(posts, akismet)
+	}
+
+	checkers := SpamCheckers{Comments: spam.NewChain(comments...)}
+	if util.EnvBool("SPAM_CHECK_POSTS", false) {
+		checkers.Posts = spam.NewChain(posts...)
+	}
+	return checkers
+}
+
+// commentSpamContent 构造评论的检测内容。
+func commentSpamContent(c *model.Comment) *spam.Content {
+	return &spam.Content{
+		Kind:      spam.KindComment,
+		UserID:    c.UserId,
+		Author:    c.User.Username,
+		Email:     c.User.Email,
+		IP:        c.IP,
+		UserAgent: c.UserAgent,
+		Body:      c.Content,
+		Permalink: postPermalink(c.PostId),
+	}
+}
+
+// commentSpamContentFor 构造评论的检测内容；新建或编辑中的评论未预加载评论者时，按 UserId 查询以填充用户名与邮箱。
+func (s *CommentService) commentSpamContentFor(ctx context.Context, c *model.Comment) (*spam.Content, error) {
+	content := commentSpamContent(c)
+	if c.User.ID == 0 && c.UserId != 0 {
+		u, err := s.userRepo.FindByID(ctx, c.UserId)
+		if err != nil {
+			return nil, err
+		}
+		content.Author, content.Email = u.Username, u.Email
+	}
+	return content, nil
+}
+
+// postPermalink 返回文章在站点上的地址（与静态导出一致），未配置 SITE_BASE_URL 时为空。
+func postPermalink(postID uint) string {
+	base := strings.TrimRight(util.EnvString("SITE_BASE_URL", ""), "/")
+	if base == "" {
+		return ""
+	}
+	return base + "/posts/" + strconv.FormatUint(uint64(postID), 10) + "/"
+}
+
+// spamLabelFor 审核状态对应的训练标签：通过为正常样本，垃圾为垃圾样本，其余不训练。
+func spamLabelFor(status string) spam.Label {
+	switch status {
+	case model.CommentStatusApproved:
+		return spam.LabelHam
+	case model.CommentStatusSpam:
+		return spam.LabelSpam
+	}
+	return spam.LabelNone
+}
+
+// trainSpam 将审核员的判定反馈给检测器，并记录训练标签避免重复计数。
+func (s *CommentService) trainSpam(ctx context.Context, ids []uint, status string) {
+	trainer, ok := s.spamChecker.(spam.Trainer)
+	label := spamLabelFor(status)
+	if !ok || label == spam.LabelNone {
+		return
+	}
+	comments, err := s.commentRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		log.Printf("train spam checker error: %v", err)
+		return
+	}
+	for i := range comments {
+		c := &comments[i]
+		previous := spam.Label(c.SpamLabel)
+		if previous == label {
+			continue
+		}
+		if err := trainer.Train(ctx, commentSpamContent(c), label, previous); err != nil {
+			log.Printf("train spam checker error: %v", err)
+			continue
+		}
+		if err := s.commentRepo.SetSpamLabel(ctx, c.Id, string(label)); err != nil {
+			log.Printf("train spam checker error: %v", err)
+		}
+	}
+}
+
+// truncate 按字符截断字符串，保证不超过列长度。
+func truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
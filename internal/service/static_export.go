@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go-blog/internal/export"
+	"go-blog/internal/model"
+	"go-blog/internal/repository"
+)
+
+// StaticExportService 将已发布的公开文章导出为静态 HTML 站点。
+type StaticExportService struct {
+	postRepo     *repository.PostRepository
+	categoryRepo *repository.CategoryRepository
+	tagRepo      *repository.TagRepository
+	commentRepo  *repository.CommentRepository
+}
+
+// NewStaticExportService 构造静态导出服务。
+func NewStaticExportService(postRepo *repository.PostRepository, categoryRepo *repository.CategoryRepository, tagRepo *repository.TagRepository, commentRepo *repository.CommentRepository) *StaticExportService {
+	return &StaticExportService{
+		postRepo:     postRepo,
+		categoryRepo: categoryRepo,
+		tagRepo:      tagRepo,
+		commentRepo:  commentRepo,
+	}
+}
+
+// StaticExportOptions 静态导出参数。
+type StaticExportOptions struct {
+	OutDir    string // 输出目录（整体替换）
+	AssetsDir string // 上传文件目录，复制到 static/uploads
+	BaseURL   string // 站点地址，用于 RSS 绝对链接与子路径部署
+	Title     string
+	PageSize  int
+}
+
+// Export 导出站点：只包含已发布且公开的文章（私密、不公开与密码保护文章不导出）及其评论。
+func (s *StaticExportService) Export(ctx context.Context, opts StaticExportOptions) (*export.Stats, error) {
+	posts, err := s.postRepo.ListPublic(ctx)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.categoryRepo.ListOrdered(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := s.tagRepo.ListOrdered(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	site := &export.Site{
+		Title:       opts.Title,
+		BaseURL:     opts.BaseURL,
+		PageSize:    opts.PageSize,
+		GeneratedAt: time.Now(),
+		Posts:       make([]*export.Post, 0, len(posts)),
+	}
+	for _, c := range categories {
+		site.Categories = append(site.Categories, export.Term{Name: c.Name, Slug: c.Slug})
+	}
+	for _, t := range tags {
+		site.Tags = append(site.Tags, export.Term{Name: t.Name, Slug: t.Slug})
+	}
+	for i := range posts {
+		p, err := s.exportPost(ctx, &posts[i])
+		if err != nil {
+			return nil, err
+		}
+		site.Posts = append(site.Posts, p)
+	}
+	return export.Render(opts.OutDir, opts.AssetsDir, site)
+}
+
+// exportPost 转换单篇文章并附上按会话顺序排列的评论。
+func (s *StaticExportService) exportPost(ctx context.Context, post *model.Post) (*export.Post, error) {
+	p := &export.Post{
+		ID:        post.ID,
+		Title:     post.Title,
+		Content:   post.Content,
+		CreatedAt: post.CreatedAt,
+		UpdatedAt: post.UpdatedAt,
+	}
+	if post.User != nil {
+		p.Author = post.User.Username
+	}
+	if post.Category.Id != 0 {
+		p.Category = &export.Term{Name: post.Category.Name, Slug: post.Category.Slug}
+	}
+	for _, t := range post.Tags {
+		p.Tags = append(p.Tags, export.Term{Name: t.Name, Slug: t.Slug})
+	}
+
+	comments, err := s.commentRepo.ListByPostID(ctx, post.ID)
+	if err != nil {
+		return nil, err
+	}
+	children := make(map[uint][]model.Comment)
+	var roots []model.Comment
+	ids := make(map[uint]bool, len(comments))
+	for _, c := range comments {
+		ids[c.Id] = true
+	}
+	for _, c := range comments {
+		if c.ParentId != nil && ids[*c.ParentId] {
+			children[*c.ParentId] = append(children[*c.ParentId], c)
+		} else {
+			roots = append(roots, c)
+		}
+	}
+	var walk func(list []model.Comment, depth int)
+	walk = func(list []model.Comment, depth int) {
+		for _, c := range list {
+			item := export.Comment{
+				ID:        c.Id,
+				Author:    c.User.Username,
+				Content:   c.Content,
+				Depth:     depth,
+				CreatedAt: c.CreatedAt,
+			}
+			if c.Tombstone {
+				item.Author, item.Content = "", model.DeletedCommentContent
+			}
+			p.Comments = append(p.Comments, item)
+			walk(children[c.Id], depth+1)
+		}
+	}
+	walk(roots, 0)
+	return p, nil
+}
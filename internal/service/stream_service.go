@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+
+	"go-blog/internal/realtime"
+	"go-blog/internal/util"
+)
+
+// 实时推送的事件名。
+const (
+	StreamCommentCreated = "comment.created"
+	StreamCommentUpdated = "comment.updated"
+	StreamCommentDeleted = "comment.deleted"
+	StreamNotification   = "notification"
+	StreamUnread         = "unread"
+)
+
+// StreamService 通过发布/订阅代理向 SSE 连接推送文章评论与用户通知。
+type StreamService struct {
+	Broker    realtime.Broker
+	Heartbeat time.Duration // SSE 心跳间隔，防止代理与负载均衡断开空闲连接
+}
+
+// NewStreamService 构造实时推送服务，并读取心跳间隔 SSE_HEARTBEAT（秒，默认 15）。
+func NewStreamService(broker realtime.Broker) *StreamService {
+	heartbeat := util.EnvSeconds("SSE_HEARTBEAT", 15)
+	if heartbeat <= 0 {
+		heartbeat = 15 * time.Second
+	}
+	return &StreamService{Broker: broker, Heartbeat: heartbeat}
+}
+
+// LoadBroker 构造单实例使用的进程内代理：每个主题保留 SSE_HISTORY 条事件（默认 100）用于续传，
+// 无订阅者的主题保留 SSE_HISTORY_TTL 秒（默认 300）。多实例部署时改为注入共享代理的 realtime.Broker 实现。
+func LoadBroker() realtime.Broker {
+	return realtime.NewMemoryBroker(util.EnvInt("SSE_HISTORY", 100), util.EnvSeconds("SSE_HISTORY_TTL", 300))
+}
+
+// PostTopic 文章评论流的主题。
+func PostTopic(postID uint) string {
+	return "post:" + strconv.FormatUint(uint64(postID), 10)
+}
+
+// UserTopic 用户通知流的主题。
+func UserTopic(uid uint) string {
+	return "user:" + strconv.FormatUint(uint64(uid), 10)
+}
+
+// Publish 向主题推送事件；推送是业务操作的附带效果，失败只记录日志。s 为 nil 时不做任何事。
+func (s *StreamService) Publish(ctx context.Context, topic, typ string, v any) {
+	if s == nil {
+		return
+	}
+	data, err := json.Marshal(v)
+	if err == nil {
+		_, err = s.Broker.Publish(ctx, topic, typ, data)
+	}
+	if err != nil {
+		log.Printf("publish %s to %s error: %v", typ, topic, err)
+	}
+}
+
+// Subscribe 订阅主题，lastEventID 为客户端最后收到的事件 ID（可为空）。
+func (s *StreamService) Subscribe(ctx context.Context, topic, lastEventID string) (<-chan realtime.Event, error) {
+	return s.Broker.Subscribe(ctx, topic, lastEventID)
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"go-blog/internal/dto"
+	"go-blog/internal/repository"
+	"go-blog/internal/util"
+	"gorm.io/gorm"
+)
+
+// ErrNotInTrash 目标不在回收站中。
+var ErrNotInTrash = errors.New("not in trash")
+
+// TrashService 处理回收站的查看、恢复、永久删除与过期清理。
+type TrashService struct {
+	postRepo    *repository.PostRepository
+	commentRepo *repository.CommentRepository
+	related     *RelatedService
+	retention   time.Duration
+	interval    time.Duration
+}
+
+// NewTrashService 构造回收站服务，保留天数由 TRASH_RETENTION_DAYS 配置。
+func NewTrashService(postRepo *repository.PostRepository, commentRepo *repository.CommentRepository, related *RelatedService) *TrashService {
+	return &TrashService{
+		postRepo:    postRepo,
+		commentRepo: commentRepo,
+		related:     related,
+		retention:   time.Duration(util.EnvInt("TRASH_RETENTION_DAYS", 30)) * 24 * time.Hour,
+		interval:    util.EnvMinutes("TRASH_PURGE_INTERVAL", 60),
+	}
+}
+
+// ListTrashedPosts 分页查询回收站文章；userID 为空时查询全部（管理端）。
+func (s *TrashService) ListTrashedPosts(ctx context.Context, userID *uint, page, pageSize int) ([]dto.TrashPostResp, int64, error) {
+	posts, total, err := s.postRepo.ListTrashed(ctx, repository.TrashFilter{
+		UserID:   userID,
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	resp := make([]dto.TrashPostResp, 0, len(posts))
+	for _, p := range posts {
+		resp = append(resp, dto.TrashPostResp{
+			Id:        p.ID,
+			Title:     p.Title,
+			Status:    p.Status,
+			UserId:    p.UserID,
+			DeletedAt: p.DeletedAt.Time,
+			PurgeAt:   p.DeletedAt.Time.Add(s.retention),
+		})
+	}
+	return resp, total, nil
+}
+
+// ListTrashedComments 分页查询回收站评论；userID 为空时查询全部（管理端）。
+func (s *TrashService) ListTrashedComments(ctx context.Context, userID *uint, page, pageSize int) ([]dto.TrashCommentResp, int64, error) {
+	comments, total, err := s.commentRepo.ListTrashed(ctx, repository.TrashFilter{
+		UserID:   userID,
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	resp := make([]dto.TrashCommentResp, 0, len(comments))
+	for _, c := range comments {
+		resp = append(resp, dto.TrashCommentResp{
+			Id:        c.Id,
+			Content:   c.Content,
+			PostId:    c.PostId,
+			UserId:    c.UserId,
+			DeletedAt: c.DeletedAt.Time,
+			PurgeAt:   c.DeletedAt.Time.Add(s.retention),
+		})
+	}
+	return resp, total, nil
+}
+
+// RestorePost 从回收站恢复文章，仅所有者可操作。
+func (s *TrashService) RestorePost(ctx context.Context, uid, id uint) error {
+	post, err := s.postRepo.FindTrashedByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotInTrash
+		}
+		return err
+	}
+	if post.UserID != uid {
+		return ErrForbidden
+	}
+	if err := s.postRepo.Restore(ctx, id); err != nil {
+		return err
+	}
+	s.invalidateRelated()
+	return nil
+}
+
+// RestoreComment 从回收站恢复评论，仅评论作者可操作。
+func (s *TrashService) RestoreComment(ctx context.Context, uid, id uint) error {
+	comment, err := s.commentRepo.FindTrashedByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotInTrash
+		}
+		return err
+	}
+	if comment.UserId != uid {
+		return ErrForbidden
+	}
+	return s.commentRepo.Restore(ctx, id)
+}
+
+// PurgePost 永久删除回收站中的文章及其依赖数据（管理端）。
+func (s *TrashService) PurgePost(ctx context.Context, id uint) error {
+	if _, err := s.postRepo.FindTrashedByID(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotInTrash
+		}
+		return err
+	}
+	if err := s.postRepo.Purge(ctx, id); err != nil {
+		return err
+	}
+	s.invalidateRelated()
+	return nil
+}
+
+// PurgeComment 永久删除回收站中的评论（管理端）。
+func (s *TrashService) PurgeComment(ctx context.Context, id uint) error {
+	if _, err := s.commentRepo.FindTrashedByID(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotInTrash
+		}
+		return err
+	}
+	return s.commentRepo.Purge(ctx, id)
+}
+
+// PurgeExpired 永久删除超过保留期的文章与评论。
+func (s *TrashService) PurgeExpired(ctx context.Context) error {
+	cutoff := time.Now().Add(-s.retention)
+
+	postIDs, err := s.postRepo.ListTrashedIDsBefore(ctx, cutoff)
+	if err != nil {
+		return err
+	}
+	for _, id := range postIDs {
+		if err := s.postRepo.Purge(ctx, id); err != nil {
+			return err
+		}
+	}
+	if len(postIDs) > 0 {
+		s.invalidateRelated()
+	}
+
+	commentIDs, err := s.commentRepo.ListTrashedIDsBefore(ctx, cutoff)
+	if err != nil {
+		return err
+	}
+	for _, id := range commentIDs {
+		if err := s.commentRepo.Purge(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run 启动后立即清理一次，之后按固定间隔清理过期条目，直到 ctx 结束。
+func (s *TrashService) Run(ctx context.Context) {
+	if err := s.PurgeExpired(ctx); err != nil {
+		log.Printf("purge expired trash error: %v", err)
+	}
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.PurgeExpired(ctx); err != nil {
+				log.Printf("purge expired trash error: %v", err)
+			}
+		}
+	}
+}
+
+// invalidateRelated 文章恢复或永久删除后清空相关推荐缓存。
+func (s *TrashService) invalidateRelated() {
+	if s.related != nil {
+		s.related.Invalidate()
+	}
+}
@@ -47,6 +47,19 @@ func (s *UploadService) UploadMulti(_ context.Context, files []*multipart.FileHe
 	return urls, nil
 }
 
+// UploadBytes 保存内存中的图片数据（批量导入时使用）并返回可访问URL。
+func (s *UploadService) UploadBytes(_ context.Context, name string, data []byte) (string, error) {
+	if err := util.ValidateImageBytes(name, data, maxUploadSize); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidUpload, err)
+	}
+	filename := util.RandomFilename(filepath.Ext(name))
+	relative, err := s.repo.SaveBytes(util.DateDir(), filename, data)
+	if err != nil {
+		return "", err
+	}
+	return staticUploadPath + "/" + relative, nil
+}
+
 // persistFile 校验文件并保存到磁盘，返回相对路径。
 func (s *UploadService) persistFile(file *multipart.FileHeader) (string, error) {
 	if err := util.ValidateImageFile(file, maxUploadSize); err != nil {
@@ -3,26 +3,37 @@ package service
 import (
 	"context"
 	"errors"
+	"go-blog/internal/dto"
 	"go-blog/internal/model"
 	"go-blog/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 // 用户业务错误定义。
 var (
 	ErrorForbidden = errors.New("forbidden")
+	ErrBlockSelf   = errors.New("cannot block yourself")
 )
 
 // UserService 处理用户个人信息与文章列表业务。
 type UserService struct {
-	UserRepo *repository.UserRepository
-	PostRepo *repository.PostRepository
+	UserRepo     *repository.UserRepository
+	PostRepo     *repository.PostRepository
+	CommentRepo  *repository.CommentRepository
+	BookmarkRepo *repository.BookmarkRepository
+	BlockRepo    *repository.BlockRepository
 }
 
 // NewUserService 构造用户服务。
-func NewUserService(userRepo *repository.UserRepository, postRepo *repository.PostRepository) *UserService {
+func NewUserService(userRepo *repository.UserRepository, postRepo *repository.PostRepository, commentRepo *repository.CommentRepository, bookmarkRepo *repository.BookmarkRepository, blockRepo *repository.BlockRepository) *UserService {
 	return &UserService{
-		UserRepo: userRepo,
-		PostRepo: postRepo,
+		UserRepo:     userRepo,
+		PostRepo:     postRepo,
+		CommentRepo:  commentRepo,
+		BookmarkRepo: bookmarkRepo,
+		BlockRepo:    blockRepo,
 	}
 }
 
@@ -36,5 +47,109 @@ func (s *UserService) ListUserPosts(cxt context.Context, requesterID, targetUser
 	if requesterID != targetUserID {
 		return nil, ErrorForbidden
 	}
-	return s.PostRepo.ListByUserID(cxt, targetUserID)
+	posts, err := s.PostRepo.ListByUserID(cxt, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+	maskProtected(requesterID, postPtrs(posts)...)
+	return posts, nil
+}
+
+// ExportData 导出当前用户的个人数据：资料、文章、评论、收藏与阅读清单。
+func (s *UserService) ExportData(cxt context.Context, uid uint) (*dto.UserExport, error) {
+	u, err := s.UserRepo.FindByID(cxt, uid)
+	if err != nil {
+		return nil, err
+	}
+	posts, err := s.PostRepo.ListByUserID(cxt, uid)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := s.CommentRepo.ListByUserID(cxt, uid)
+	if err != nil {
+		return nil, err
+	}
+	bookmarks, err := s.BookmarkRepo.ListAllByUser(cxt, uid)
+	if err != nil {
+		return nil, err
+	}
+	maskBookmarked(uid, bookmarks)
+	lists, err := s.BookmarkRepo.ListListsByUser(cxt, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &dto.UserExport{
+		ExportedAt: time.Now(),
+		Profile: dto.ExportProfile{
+			Id:        u.ID,
+			Username:  u.Username,
+			Email:     u.Email,
+			Role:      u.Role,
+			CreatedAt: u.CreatedAt,
+		},
+		Posts:        make([]dto.ExportPost, 0, len(posts)),
+		Comments:     make([]dto.ExportComment, 0, len(comments)),
+		Bookmarks:    ToBookmarkResps(bookmarks),
+		ReadingLists: make([]dto.ReadingListResp, 0, len(lists)),
+	}
+	for _, p := range posts {
+		export.Posts = append(export.Posts, dto.ExportPost{
+			Id:        p.ID,
+			Title:     p.Title,
+			Content:   p.Content,
+			Status:    p.Status,
+			CreatedAt: p.CreatedAt,
+			UpdatedAt: p.UpdatedAt,
+		})
+	}
+	for _, c := range comments {
+		export.Comments = append(export.Comments, dto.ExportComment{
+			Id:        c.Id,
+			PostId:    c.PostId,
+			ParentId:  c.ParentId,
+			Content:   c.Content,
+			CreatedAt: c.CreatedAt,
+		})
+	}
+	for _, l := range lists {
+		export.ReadingLists = append(export.ReadingLists, ToReadingListResp(l))
+	}
+	return export, nil
+}
+
+// BlockUser 屏蔽用户：被屏蔽者在文章与评论中 @当前用户 不再生效。
+func (s *UserService) BlockUser(cxt context.Context, uid, targetID uint) error {
+	if uid == targetID {
+		return ErrBlockSelf
+	}
+	if _, err := s.UserRepo.FindByID(cxt, targetID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	return s.BlockRepo.Block(cxt, uid, targetID)
+}
+
+// UnblockUser 取消屏蔽，未屏蔽时不报错。
+func (s *UserService) UnblockUser(cxt context.Context, uid, targetID uint) error {
+	return s.BlockRepo.Unblock(cxt, uid, targetID)
+}
+
+// ListBlocked 分页返回当前用户屏蔽的用户。
+func (s *UserService) ListBlocked(cxt context.Context, uid uint, page, pageSize int) ([]dto.BlockedUserResp, int64, error) {
+	blocks, total, err := s.BlockRepo.ListBlocked(cxt, uid, page, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	list := make([]dto.BlockedUserResp, 0, len(blocks))
+	for _, b := range blocks {
+		item := dto.BlockedUserResp{User: dto.UserBrief{Id: b.BlockedId}, CreatedAt: b.CreatedAt}
+		if b.Blocked != nil {
+			item.User.Username = b.Blocked.Username
+		}
+		list = append(list, item)
+	}
+	return list, total, nil
 }
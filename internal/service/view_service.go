@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"go-blog/internal/repository"
+	"go-blog/internal/util"
+)
+
+// ViewCounter 在内存中累积文章浏览量，按访客去重并定期批量落库。
+type ViewCounter struct {
+	repo     *repository.PostRepository
+	window   time.Duration
+	interval time.Duration
+
+	mu      sync.Mutex
+	seen    map[string]time.Time // 访客+文章 -> 去重截止时间
+	pending map[uint]int64       // 文章ID -> 未落库的增量
+}
+
+// NewViewCounter 构造浏览计数器。
+// 去重窗口由 VIEW_DEDUP_WINDOW（分钟，默认 30）配置，
+// 落库间隔由 VIEW_FLUSH_INTERVAL（秒，默认 10）配置。
+func NewViewCounter(repo *repository.PostRepository) *ViewCounter {
+	return &ViewCounter{
+		repo:     repo,
+		window:   util.EnvMinutes("VIEW_DEDUP_WINDOW", 30),
+		interval: util.EnvSeconds("VIEW_FLUSH_INTERVAL", 10),
+		seen:     make(map[string]time.Time),
+		pending:  make(map[uint]int64),
+	}
+}
+
+// Record 记录一次浏览；同一访客在去重窗口内重复访问不计数。返回是否计数。
+func (v *ViewCounter) Record(postID uint, visitor string) bool {
+	if postID == 0 || visitor == "" {
+		return false
+	}
+	key := visitor + "|" + strconv.FormatUint(uint64(postID), 10)
+	now := time.Now()
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if until, ok := v.seen[key]; ok && now.Before(until) {
+		return false
+	}
+	v.seen[key] = now.Add(v.window)
+	v.pending[postID]++
+	return true
+}
+
+// Pending 返回文章尚未落库的浏览增量，用于详情页展示实时数字。
+func (v *ViewCounter) Pending(postID uint) int64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.pending[postID]
+}
+
+// Flush 将缓冲的增量写入数据库，并清理过期的去重记录。
+// 写库失败时增量会被放回缓冲区，等待下次重试。
+func (v *ViewCounter) Flush(ctx context.Context) error {
+	v.mu.Lock()
+	batch := v.pending
+	v.pending = make(map[uint]int64)
+	now := time.Now()
+	for k, until := range v.seen {
+		if !now.Before(until) {
+			delete(v.seen, k)
+		}
+	}
+	v.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+	if err := v.repo.IncrementViews(ctx, batch); err != nil {
+		v.mu.Lock()
+		for id, n := range batch {
+			v.pending[id] += n
+		}
+		v.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Run 按固定间隔落库，ctx 结束时做最后一次落库后退出。
+func (v *ViewCounter) Run(ctx context.Context) {
+	ticker := time.NewTicker(v.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := v.Flush(context.Background()); err != nil {
+				log.Printf("flush views error: %v", err)
+			}
+			return
+		case <-ticker.C:
+			if err := v.Flush(ctx); err != nil {
+				log.Printf("flush views error: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package spam
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultAkismetURL Akismet REST 接口地址。
+const DefaultAkismetURL = "https://rest.akismet.com"
+
+// Akismet Akismet 风格 HTTP 服务的适配器（comment-check / submit-spam / submit-ham）。
+// BaseURL 可指向兼容的自建服务或本地模拟服务。
+type Akismet struct {
+	BaseURL string
+	Key     string
+	Blog    string
+	Client  *http.Client
+}
+
+// NewAkismet 构造适配器，baseURL 为空时使用 DefaultAkismetURL。
+func NewAkismet(baseURL, key, blog string) *Akismet {
+	if baseURL == "" {
+		baseURL = DefaultAkismetURL
+	}
+	return &Akismet{
+		BaseURL: strings.TrimRight(baseURL, "/"),
+		Key:     key,
+		Blog:    blog,
+		Client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Name 实现 SpamChecker。
+func (a *Akismet) Name() string { return "akismet" }
+
+// Check 调用 comment-check：响应体为 "true" 判定为垃圾，"false" 为正常，其他视为错误。
+func (a *Akismet) Check(ctx context.Context, c *Content) (Result, error) {
+	body, header, err := a.call(ctx, "comment-check", c)
+	if err != nil {
+		return Result{}, err
+	}
+	switch body {
+	case "true":
+		reason := "akismet"
+		if header.Get("X-akismet-pro-tip") == "discard" {
+			reason = "akismet: blatant spam"
+		}
+		return Result{Spam: true, Reason: reason}, nil
+	case "false":
+		return Result{}, nil
+	}
+	if help := header.Get("X-akismet-debug-help"); help != "" {
+		return Result{}, fmt.Errorf("akismet: %s", help)
+	}
+	return Result{}, fmt.Errorf("akismet: unexpected response %q", body)
+}
+
+// Train 实现 Trainer：按标签调用 submit-spam 或 submit-ham。
+func (a *Akismet) Train(ctx context.Context, c *Content, label, previous Label) error {
+	method := ""
+	switch label {
+	case LabelSpam:
+		method = "submit-spam"
+	case LabelHam:
+		method = "submit-ham"
+	default:
+		return nil
+	}
+	if label == previous {
+		return nil
+	}
+	_, _, err := a.call(ctx, method, c)
+	return err
+}
+
+func (a *Akismet) call(ctx context.Context, method string, c *Content) (string, http.Header, error) {
+	form := url.Values{}
+	form.Set("api_key", a.Key)
+	form.Set("blog", a.Blog)
+	form.Set("user_ip", c.IP)
+	form.Set("user_agent", c.UserAgent)
+	form.Set("permalink", c.Permalink)
+	form.Set("comment_author", c.Author)
+	form.Set("comment_author_email", c.Email)
+	form.Set("comment_content", strings.TrimSpace(c.Title+"\n"+c.Body))
+	if c.Kind == KindPost {
+		form.Set("comment_type", "blog-post")
+	} else {
+		form.Set("comment_type", "comment")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.BaseURL+"/1.1/"+method, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := a.Client.Do(req)
+	if err != nil {
+		return "", nil, err
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return "", nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("akismet %s: status %d", method, resp.StatusCode)
+	}
+	return strings.TrimSpace(string(raw)), resp.Header, nil
+}
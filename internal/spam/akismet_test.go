@@ -0,0 +1,143 @@
+package spam
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeAkismet 本地模拟的 Akismet 服务：comment_content 含 "viagra" 判为垃圾，含 "broken" 返回调试信息，
+// 并记录收到的请求路径与表单。
+type fakeAkismet struct {
+	mu    sync.Mutex
+	calls []string
+	forms []url.Values
+}
+
+func (f *fakeAkismet) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.mu.Lock()
+	f.calls = append(f.calls, r.URL.Path)
+	f.forms = append(f.forms, r.PostForm)
+	f.mu.Unlock()
+
+	if r.PostForm.Get("api_key") != "test-key" {
+		w.Header().Set("X-akismet-debug-help", "Invalid API key")
+		w.Write([]byte("invalid"))
+		return
+	}
+	switch r.URL.Path {
+	case "/1.1/comment-check":
+		content := r.PostForm.Get("comment_content")
+		switch {
+		case strings.Contains(content, "viagra"):
+			w.Header().Set("X-akismet-pro-tip", "discard")
+			w.Write([]byte("true"))
+		case strings.Contains(content, "broken"):
+			w.Header().Set("X-akismet-debug-help", "Empty comment_content")
+			w.Write([]byte("invalid"))
+		default:
+			w.Write([]byte("false"))
+		}
+	case "/1.1/submit-spam", "/1.1/submit-ham":
+		w.Write([]byte("Thanks for making the web a better place."))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newFakeAkismet(t *testing.T, key string) (*Akismet, *fakeAkismet) {
+	t.Helper()
+	fake := &fakeAkismet{}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	return NewAkismet(srv.URL+"/", key, "https://blog.example.com"), fake
+}
+
+func TestAkismetCheck(t *testing.T) {
+	a, fake := newFakeAkismet(t, "test-key")
+	ctx := context.Background()
+
+	res, err := a.Check(ctx, &Content{Kind: KindComment, Author: "bob", Email: "bob@example.com", IP: "127.0.0.1", Body: "buy viagra"})
+	if err != nil {
+		t.Fatalf("check spam: %v", err)
+	}
+	if !res.Spam || res.Reason != "akismet: blatant spam" {
+		t.Fatalf("spam result = %+v", res)
+	}
+	form := fake.forms[0]
+	if form.Get("comment_author") != "bob" || form.Get("comment_author_email") != "bob@example.com" ||
+		form.Get("blog") != "https://blog.example.com" || form.Get("comment_type") != "comment" {
+		t.Fatalf("unexpected form: %v", form)
+	}
+
+	res, err = a.Check(ctx, &Content{Kind: KindPost, Title: "Hello", Body: "nice article"})
+	if err != nil {
+		t.Fatalf("check ham: %v", err)
+	}
+	if res.Spam {
+		t.Fatalf("ham result = %+v", res)
+	}
+	if got := fake.forms[1].Get("comment_type"); got != "blog-post" {
+		t.Fatalf("comment_type = %q, want blog-post", got)
+	}
+
+	if _, err := a.Check(ctx, &Content{Body: "broken"}); err == nil || !strings.Contains(err.Error(), "Empty comment_content") {
+		t.Fatalf("debug help error = %v", err)
+	}
+}
+
+func TestAkismetInvalidKey(t *testing.T) {
+	a, _ := newFakeAkismet(t, "wrong-key")
+	if _, err := a.Check(context.Background(), &Content{Body: "hello"}); err == nil || !strings.Contains(err.Error(), "Invalid API key") {
+		t.Fatalf("invalid key error = %v", err)
+	}
+}
+
+func TestAkismetTrain(t *testing.T) {
+	a, fake := newFakeAkismet(t, "test-key")
+	ctx := context.Background()
+	c := &Content{Kind: KindComment, Body: "hello"}
+
+	if err := a.Train(ctx, c, LabelSpam, LabelNone); err != nil {
+		t.Fatalf("submit spam: %v", err)
+	}
+	if err := a.Train(ctx, c, LabelHam, LabelSpam); err != nil {
+		t.Fatalf("submit ham: %v", err)
+	}
+	// 标签未变化或无标签时不调用服务
+	if err := a.Train(ctx, c, LabelHam, LabelHam); err != nil {
+		t.Fatalf("repeat ham: %v", err)
+	}
+	if err := a.Train(ctx, c, LabelNone, LabelHam); err != nil {
+		t.Fatalf("no label: %v", err)
+	}
+
+	want := []string{"/1.1/submit-spam", "/1.1/submit-ham"}
+	if len(fake.calls) != len(want) {
+		t.Fatalf("calls = %v, want %v", fake.calls, want)
+	}
+	for i := range want {
+		if fake.calls[i] != want[i] {
+			t.Fatalf("calls = %v, want %v", fake.calls, want)
+		}
+	}
+}
+
+func TestAkismetServerError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+	a := NewAkismet(srv.URL, "test-key", "")
+	if err := a.Train(context.Background(), &Content{}, LabelSpam, LabelNone); err == nil {
+		t.Fatal("expected error on non-200 status")
+	}
+}
@@ -0,0 +1,149 @@
+package spam
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"go-blog/internal/model"
+)
+
+// DocsToken 记录已训练的垃圾/正常样本数的保留词（分词结果不会包含 '#'）。
+const DocsToken = "#docs"
+
+// maxTokens 单条内容参与训练与分类的最多词数。
+const maxTokens = 200
+
+// TokenStore 贝叶斯分类器的词频存储。
+type TokenStore interface {
+	// Counts 查询词的垃圾/正常样本出现次数，不存在的词不返回。
+	Counts(ctx context.Context, tokens []string) ([]model.SpamToken, error)
+	// Add 将词在垃圾（spam=true）或正常样本中的计数加上 delta（可为负，结果不小于 0）。
+	Add(ctx context.Context, tokens []string, spam bool, delta int64) error
+}
+
+// Bayes 朴素贝叶斯分类器，由审核员的垃圾/正常判定训练。
+// 两类样本都达到 MinDocs 之前不做判定，垃圾概率不低于 Threshold 时判定为垃圾。
+type Bayes struct {
+	Store     TokenStore
+	Threshold float64
+	MinDocs   int64
+}
+
+// Name 实现 SpamChecker。
+func (b *Bayes) Name() string { return "bayes" }
+
+// Check 计算内容为垃圾的后验概率（拉普拉斯平滑，忽略未见过的词）。
+func (b *Bayes) Check(ctx context.Context, c *Content) (Result, error) {
+	tokens := Tokenize(c.Title + "\n" + c.Body)
+	if len(tokens) == 0 {
+		return Result{}, nil
+	}
+	rows, err := b.Store.Counts(ctx, append(tokens, DocsToken))
+	if err != nil {
+		return Result{}, err
+	}
+	var docs model.SpamToken
+	counts := make(map[string]model.SpamToken, len(rows))
+	for _, row := range rows {
+		if row.Token == DocsToken {
+			docs = row
+		} else {
+			counts[row.Token] = row
+		}
+	}
+	if docs.Spam < b.MinDocs || docs.Ham < b.MinDocs {
+		return Result{}, nil
+	}
+
+	spamDocs, hamDocs := float64(docs.Spam), float64(docs.Ham)
+	logOdds := math.Log(spamDocs / hamDocs)
+	for _, t := range tokens {
+		row, ok := counts[t]
+		if !ok {
+			continue
+		}
+		ps := (float64(row.Spam) + 1) / (spamDocs + 2)
+		ph := (float64(row.Ham) + 1) / (hamDocs + 2)
+		logOdds += math.Log(ps / ph)
+	}
+	p := 1 / (1 + math.Exp(-logOdds))
+	if p >= b.Threshold {
+		return Result{Spam: true, Reason: fmt.Sprintf("bayes probability %.3f", p)}, nil
+	}
+	return Result{}, nil
+}
+
+// Train 实现 Trainer：撤销旧标签的样本后按新标签计数。
+func (b *Bayes) Train(ctx context.Context, c *Content, label, previous Label) error {
+	if label == previous {
+		return nil
+	}
+	tokens := append(Tokenize(c.Title+"\n"+c.Body), DocsToken)
+	if previous != LabelNone {
+		if err := b.Store.Add(ctx, tokens, previous == LabelSpam, -1); err != nil {
+			return err
+		}
+	}
+	if label == LabelNone {
+		return nil
+	}
+	return b.Store.Add(ctx, tokens, label == LabelSpam, 1)
+}
+
+// Tokenize 将文本切分为去重后的小写词：字母数字连续串作为一个词（2~32 个字符），
+// 汉字等无空格分隔的文字按相邻两字切分。最多返回 maxTokens 个词。
+func Tokenize(text string) []string {
+	return TokenizeN(text, maxTokens)
+}
+
+// TokenizeN 与 Tokenize 规则相同，最多返回 limit 个词；limit <= 0 表示不限。
+func TokenizeN(text string, limit int) []string {
+	seen := make(map[string]bool)
+	var tokens []string
+	add := func(t string) {
+		if !seen[t] && (limit <= 0 || len(tokens) < limit) {
+			seen[t] = true
+			tokens = append(tokens, t)
+		}
+	}
+
+	var word []rune
+	var han []rune
+	flushWord := func() {
+		if n := len(word); n >= 2 && n <= 32 {
+			add(string(word))
+		}
+		word = word[:0]
+	}
+	flushHan := func() {
+		switch {
+		case len(han) == 1:
+			add(string(han))
+		case len(han) > 1:
+			for i := 0; i+1 < len(han); i++ {
+				add(string(han[i : i+2]))
+			}
+		}
+		han = han[:0]
+	}
+
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			flushWord()
+			han = append(han, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushHan()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushHan()
+		}
+	}
+	flushWord()
+	flushHan()
+	return tokens
+}
@@ -0,0 +1,75 @@
+package spam
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Blocklist 关键词与正则黑名单：关键词不区分大小写按子串匹配。
+type Blocklist struct {
+	keywords []string
+	patterns []*regexp.Regexp
+}
+
+// NewBlocklist 构造黑名单；正则默认不区分大小写，编译失败时返回错误。
+func NewBlocklist(keywords, patterns []string) (*Blocklist, error) {
+	b := &Blocklist{}
+	for _, k := range keywords {
+		if k = strings.ToLower(strings.TrimSpace(k)); k != "" {
+			b.keywords = append(b.keywords, k)
+		}
+	}
+	for _, p := range patterns {
+		re, err := regexp.Compile("(?i)" + p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid blocklist pattern %q: %w", p, err)
+		}
+		b.patterns = append(b.patterns, re)
+	}
+	return b, nil
+}
+
+// ParseBlocklist 读取黑名单文件：每行一条，`/.../` 包裹的为正则，`#` 开头为注释。
+func ParseBlocklist(r io.Reader) (keywords, patterns []string, err error) {
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+		case len(line) > 2 && strings.HasPrefix(line, "/") && strings.HasSuffix(line, "/"):
+			patterns = append(patterns, line[1:len(line)-1])
+		default:
+			keywords = append(keywords, line)
+		}
+	}
+	return keywords, patterns, sc.Err()
+}
+
+// Empty 黑名单是否为空。
+func (b *Blocklist) Empty() bool {
+	return len(b.keywords) == 0 && len(b.patterns) == 0
+}
+
+// Name 实现 SpamChecker。
+func (b *Blocklist) Name() string { return "blocklist" }
+
+// Check 标题、正文、作者名与邮箱命中任一关键词或正则即判定为垃圾。
+func (b *Blocklist) Check(_ context.Context, c *Content) (Result, error) {
+	text := strings.Join([]string{c.Title, c.Body, c.Author, c.Email}, "\n")
+	lower := strings.ToLower(text)
+	for _, k := range b.keywords {
+		if strings.Contains(lower, k) {
+			return Result{Spam: true, Reason: "blocked keyword: " + k}, nil
+		}
+	}
+	for _, re := range b.patterns {
+		if re.MatchString(text) {
+			return Result{Spam: true, Reason: "blocked pattern: " + re.String()[4:]}, nil
+		}
+	}
+	return Result{}, nil
+}
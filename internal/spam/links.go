@@ -0,0 +1,25 @@
+package spam
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+var linkPattern = regexp.MustCompile(`(?i)\bhttps?://[^\s"'<>]+|\bwww\.[^\s"'<>]+`)
+
+// LinkLimit 链接数限制：正文中的链接超过 Max 个即判定为垃圾。
+type LinkLimit struct {
+	Max int
+}
+
+// Name 实现 SpamChecker。
+func (l *LinkLimit) Name() string { return "links" }
+
+// Check 统计以 http(s):// 或 www. 开头的链接个数。
+func (l *LinkLimit) Check(_ context.Context, c *Content) (Result, error) {
+	if n := len(linkPattern.FindAllStringIndex(c.Body, -1)); n > l.Max {
+		return Result{Spam: true, Reason: fmt.Sprintf("too many links: %d > %d", n, l.Max)}, nil
+	}
+	return Result{}, nil
+}
@@ -0,0 +1,91 @@
+package spam
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit 频率启发式：同一用户（未登录时按 IP）在 Window 内提交超过 Max 次，
+// 或重复提交相同内容，即判定为垃圾。记录保存在进程内存中。
+type RateLimit struct {
+	Window time.Duration
+	Max    int
+	Now    func() time.Time // 便于替换时钟，默认 time.Now
+
+	mu        sync.Mutex
+	recent    map[string][]submission
+	lastSweep time.Time
+}
+
+type submission struct {
+	at   time.Time
+	hash uint64
+}
+
+// NewRateLimit 构造频率检测器。
+func NewRateLimit(window time.Duration, max int) *RateLimit {
+	return &RateLimit{Window: window, Max: max, recent: make(map[string][]submission)}
+}
+
+// Name 实现 SpamChecker。
+func (r *RateLimit) Name() string { return "rate" }
+
+// Check 检查并记录本次提交（被判定为垃圾的提交同样计入）。
+func (r *RateLimit) Check(_ context.Context, c *Content) (Result, error) {
+	key := c.Kind + "|" + c.IP
+	if c.UserID > 0 {
+		key = c.Kind + "|u" + strconv.FormatUint(uint64(c.UserID), 10)
+	}
+	h := fnv.New64a()
+	h.Write([]byte(strings.Join(strings.Fields(strings.ToLower(c.Body)), " ")))
+	sum := h.Sum64()
+
+	now := time.Now()
+	if r.Now != nil {
+		now = r.Now()
+	}
+	cutoff := now.Add(-r.Window)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if now.Sub(r.lastSweep) > r.Window {
+		r.sweep(cutoff)
+		r.lastSweep = now
+	}
+	subs := pruneSubmissions(r.recent[key], cutoff)
+	r.recent[key] = append(subs, submission{at: now, hash: sum})
+
+	for _, s := range subs {
+		if s.hash == sum {
+			return Result{Spam: true, Reason: "duplicate content"}, nil
+		}
+	}
+	if len(subs) >= r.Max {
+		return Result{Spam: true, Reason: fmt.Sprintf("more than %d submissions in %s", r.Max, r.Window)}, nil
+	}
+	return Result{}, nil
+}
+
+// sweep 清理所有已过期的记录，避免长期运行后内存增长。
+func (r *RateLimit) sweep(cutoff time.Time) {
+	for k, subs := range r.recent {
+		if subs = pruneSubmissions(subs, cutoff); len(subs) == 0 {
+			delete(r.recent, k)
+		} else {
+			r.recent[k] = subs
+		}
+	}
+}
+
+func pruneSubmissions(subs []submission, cutoff time.Time) []submission {
+	i := 0
+	for i < len(subs) && !subs[i].at.After(cutoff) {
+		i++
+	}
+	return subs[i:]
+}
@@ -0,0 +1,111 @@
+// Package spam 提供可插拔的垃圾内容检测：关键词/正则黑名单、链接数限制、频率限制、
+// 贝叶斯分类器与 Akismet 风格的 HTTP 服务适配器，可组合成检测链使用。
+package spam
+
+import (
+	"context"
+	"errors"
+	"log"
+)
+
+// 内容类型。
+const (
+	KindComment = "comment"
+	KindPost    = "post"
+)
+
+// Label 训练标签。
+type Label string
+
+// 训练标签取值：LabelNone 表示尚未训练过。
+const (
+	LabelNone Label = ""
+	LabelSpam Label = "spam"
+	LabelHam  Label = "ham"
+)
+
+// Content 待检测的内容及其上下文。
+type Content struct {
+	Kind      string // comment / post
+	UserID    uint
+	Author    string
+	Email     string
+	IP        string
+	UserAgent string
+	Title     string
+	Body      string
+	Permalink string // 所属文章的地址
+}
+
+// Result 检测结果；Spam 为 false 时 Checker 与 Reason 为空。
+type Result struct {
+	Spam    bool   `json:"spam"`
+	Checker string `json:"checker,omitempty"` // 判定为垃圾内容的检测器名称
+	Reason  string `json:"reason,omitempty"`
+}
+
+// SpamChecker 垃圾内容检测器。
+type SpamChecker interface {
+	Name() string
+	Check(ctx context.Context, c *Content) (Result, error)
+}
+
+// Trainer 可根据审核员的判定学习的检测器。previous 为该内容此前训练时使用的标签，
+// 审核员改判时据此撤销旧样本；未训练过为 LabelNone。
+type Trainer interface {
+	Train(ctx context.Context, c *Content, label, previous Label) error
+}
+
+// Chain 按顺序执行多个检测器，任一判定为垃圾即返回。
+// 单个检测器出错（如外部服务不可用）只记录日志并跳过，不阻塞正常发布。
+type Chain struct {
+	checkers []SpamChecker
+}
+
+// NewChain 构造检测链，忽略 nil 检测器。
+func NewChain(checkers ...SpamChecker) *Chain {
+	c := &Chain{}
+	for _, ck := range checkers {
+		if ck != nil {
+			c.checkers = append(c.checkers, ck)
+		}
+	}
+	return c
+}
+
+// Name 实现 SpamChecker。
+func (c *Chain) Name() string { return "chain" }
+
+// Len 返回检测器数量。
+func (c *Chain) Len() int { return len(c.checkers) }
+
+// Check 依次执行检测器，返回第一个垃圾判定。
+func (c *Chain) Check(ctx context.Context, content *Content) (Result, error) {
+	for _, ck := range c.checkers {
+		res, err := ck.Check(ctx, content)
+		if err != nil {
+			log.Printf("spam checker %s error: %v", ck.Name(), err)
+			continue
+		}
+		if res.Spam {
+			if res.Checker == "" {
+				res.Checker = ck.Name()
+			}
+			return res, nil
+		}
+	}
+	return Result{}, nil
+}
+
+// Train 将审核结果交给链中所有支持训练的检测器。
+func (c *Chain) Train(ctx context.Context, content *Content, label, previous Label) error {
+	var errs []error
+	for _, ck := range c.checkers {
+		if t, ok := ck.(Trainer); ok {
+			if err := t.Train(ctx, content, label, previous); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,56 @@
+package util
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EnvString 读取字符串环境变量，不存在时返回默认值。
+func EnvString(key, def string) string {
+	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+		return v
+	}
+	return def
+}
+
+// EnvInt 读取整数环境变量，非法或非正数时返回默认值。
+func EnvInt(key string, def int) int {
+	if v := os.Getenv(key); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	return def
+}
+
+// EnvFloat 读取浮点环境变量，非法或负数时返回默认值。
+func EnvFloat(key string, def float64) float64 {
+	if v := os.Getenv(key); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil && f >= 0 {
+			return f
+		}
+	}
+	return def
+}
+
+// EnvBool 读取布尔环境变量（true/false/1/0），非法时返回默认值。
+func EnvBool(key string, def bool) bool {
+	if v := os.Getenv(key); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return def
+}
+
+// EnvSeconds 以秒为单位读取时长配置。
+func EnvSeconds(key string, defSecs int) time.Duration {
+	return time.Duration(EnvInt(key, defSecs)) * time.Second
+}
+
+// EnvMinutes 以分钟为单位读取时长配置。
+func EnvMinutes(key string, defMins int) time.Duration {
+	return time.Duration(EnvInt(key, defMins)) * time.Minute
+}
@@ -0,0 +1,63 @@
+package util
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MaxMentions 单条内容最多解析的提及数，超出的忽略。
+const MaxMentions = 20
+
+// ParseMentions 解析文本中的 @username，按出现顺序去重（不区分大小写）。
+// "@" 前须为行首或非单词字符（排除邮箱地址）；用户名由字母、数字、"_"、"."、"-" 组成（3~32 个字符，结尾的 "." 与 "-" 视为标点）。
+// Markdown 代码块与行内代码中的内容不解析。
+func ParseMentions(text string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	fenced := false
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			fenced = !fenced
+			continue
+		}
+		if fenced {
+			continue
+		}
+		rs := []rune(line)
+		inCode := false
+		for i := 0; i < len(rs); i++ {
+			switch {
+			case rs[i] == '`':
+				inCode = !inCode
+				continue
+			case inCode || rs[i] != '@':
+				continue
+			case i > 0 && (isMentionRune(rs[i-1]) || rs[i-1] == '@'):
+				continue
+			}
+			j := i + 1
+			for j < len(rs) && isMentionRune(rs[j]) {
+				j++
+			}
+			name := strings.TrimRight(string(rs[i+1:j]), ".-")
+			i = j - 1
+			if n := len([]rune(name)); n < 3 || n > 32 {
+				continue
+			}
+			key := strings.ToLower(name)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			names = append(names, name)
+			if len(names) == MaxMentions {
+				return names
+			}
+		}
+	}
+	return names
+}
+
+func isMentionRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '-'
+}
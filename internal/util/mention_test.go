@@ -0,0 +1,64 @@
+package util
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"single", "hi @alice", []string{"alice"}},
+		{"line start", "@alice hi", []string{"alice"}},
+		{"multiple in order", "@bob and @alice", []string{"bob", "alice"}},
+		{"dedup case insensitive", "@Alice @alice @ALICE", []string{"Alice"}},
+		{"email excluded", "mail alice@example.com please", nil},
+		{"email and mention", "bob@example.com cc @carol", []string{"carol"}},
+		{"double at", "@@alice", nil},
+		{"after punctuation", "(@alice), [@bob]", []string{"alice", "bob"}},
+		{"trailing period", "thanks @alice.", []string{"alice"}},
+		{"trailing dash and dots", "@alice-- @bob...", []string{"alice", "bob"}},
+		{"inner dot and dash", "@a.b-c_d", []string{"a.b-c_d"}},
+		{"trailing punctuation", "@alice, @bob! @carol? @dave:", []string{"alice", "bob", "carol", "dave"}},
+		{"too short", "@ab", nil},
+		{"min length", "@abc", []string{"abc"}},
+		{"short after trim", "@ab.", nil},
+		{"max length", "@" + strings.Repeat("a", 32), []string{strings.Repeat("a", 32)}},
+		{"too long", "@" + strings.Repeat("a", 33), nil},
+		{"unicode letters", "@张三丰 好", []string{"张三丰"}},
+		{"inline code", "see `@alice` and @bob", []string{"bob"}},
+		{"unclosed inline code", "`@alice @bob", nil},
+		{"inline code per line", "`@alice\n@bob", []string{"bob"}},
+		{"code fence", "```\n@alice\n```\n@bob", []string{"bob"}},
+		{"code fence with lang", "```go\n// @alice\n```", nil},
+		{"indented fence", "  ```\n@alice\n  ```\n@carol", []string{"carol"}},
+		{"empty", "", nil},
+		{"bare at", "@ alone", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseMentions(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseMentions(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMentionsLimit(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < MaxMentions+5; i++ {
+		fmt.Fprintf(&b, "@user%02d ", i)
+	}
+	got := ParseMentions(b.String())
+	if len(got) != MaxMentions {
+		t.Fatalf("got %d mentions, want %d", len(got), MaxMentions)
+	}
+	if got[0] != "user00" || got[MaxMentions-1] != fmt.Sprintf("user%02d", MaxMentions-1) {
+		t.Fatalf("unexpected mentions kept: %v", got)
+	}
+}
@@ -0,0 +1,24 @@
+package util
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Slugify 生成 URL 友好的 slug：小写，保留字母（含中文）与数字，其余字符折叠为 "-"
+func Slugify(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
@@ -0,0 +1,15 @@
+package util
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// RandomToken 生成 n 字节随机数的十六进制字符串，用于分享链接等不可猜测的标识。
+func RandomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
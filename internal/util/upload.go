@@ -90,3 +90,24 @@ func detectContentType(fh *multipart.FileHeader) (string, error) {
 
 	return http.DetectContentType(buf[:n]), nil
 }
+
+// ValidateImageBytes 校验内存中的图片数据（用于批量导入），规则同 ValidateImageFile
+func ValidateImageBytes(filename string, data []byte, maxSize int64) error {
+	if int64(len(data)) > maxSize {
+		return fmt.Errorf("文件过大，最大为 %d MB", maxSize/(1<<20))
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	if !allowedExt[ext] {
+		return fmt.Errorf("仅支持jpg, png, jpeg, git, webp")
+	}
+
+	n := len(data)
+	if n > 512 {
+		n = 512
+	}
+	if ct := http.DetectContentType(data[:n]); !allowedMimeTypes[ct] {
+		return fmt.Errorf("不支持的文件类型: %s", ct)
+	}
+	return nil
+}